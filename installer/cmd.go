@@ -0,0 +1,607 @@
+package main
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/spf13/cobra"
+	tea "github.com/charmbracelet/bubbletea"
+
+	"github.com/strawberry-code/ragify/internal/config"
+	"github.com/strawberry-code/ragify/internal/installer"
+	"github.com/strawberry-code/ragify/internal/ragcore"
+	"github.com/strawberry-code/ragify/internal/services"
+)
+
+// configPath is the --config flag's value; cfg is what it resolves to
+// once the root command's PersistentPreRunE has run, which every
+// subcommand (and the default TUI launch) reads from.
+var (
+	configPath string
+	cfg        *config.Config
+)
+
+// killGracePeriod is how long runTUI waits for the Bubble Tea program to
+// exit on its own after a quit signal before forcibly killing it so the
+// terminal isn't left in raw mode / the alt screen.
+const killGracePeriod = 2 * time.Second
+
+// allComponents lists every component BuildPlan knows how to install, in
+// the order doctor prints them (the plan's dependency sort, not this list,
+// decides install order). It's the source of truth for --components and for the
+// doctor/install default of "everything".
+var allComponents = []string{"docker", "ollama", "qdrant", "mcp_server", "python_deps"}
+
+// newRootCmd builds the ragify command tree. With no subcommand it falls
+// through to the interactive Bubble Tea wizard (the original behavior,
+// and the same thing the explicit `tui` subcommand does); every other
+// subcommand runs headlessly, so both the installer and the core RAG
+// actions are scriptable from shells, cron, or CI.
+func newRootCmd() *cobra.Command {
+	root := &cobra.Command{
+		Use:           "ragify",
+		Short:         "Install and drive the self-hosted RAG platform",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			// Bubble Tea needs a real terminal; with piped/redirected
+			// stdin and no explicit subcommand there's nothing sensible
+			// to launch, so just print usage instead of letting tea fail.
+			if !isTerminal(os.Stdin) {
+				return cmd.Help()
+			}
+			return runTUI()
+		},
+	}
+	SetupRootCommand(root)
+	root.PersistentFlags().StringVar(&configPath, "config", "", "path to config file (default $RAGIFY_CONFIG or ~/.config/ragify/config.yaml)")
+	root.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		loaded, err := config.Load(config.ResolvePath(configPath))
+		if err != nil {
+			return err
+		}
+		cfg = loaded
+		return nil
+	}
+
+	root.AddCommand(newInstallCmd())
+	root.AddCommand(newDoctorCmd())
+	root.AddCommand(newServiceCmd())
+	root.AddCommand(newUninstallCmd())
+	root.AddCommand(newIngestCmd())
+	root.AddCommand(newQueryCmd())
+	root.AddCommand(newServeCmd())
+	root.AddCommand(newExportCmd())
+	root.AddCommand(newTUICmd())
+	root.AddCommand(newConfigCmd())
+	return root
+}
+
+// runTUI launches the interactive installer wizard; it's the root
+// command's default action and also what `ragify tui` runs explicitly.
+// It guarantees the terminal gets restored to cooked mode even if the
+// process is signaled or the program panics mid-render, rather than
+// leaving the user's shell stuck in the alt screen.
+func runTUI() (err error) {
+	p := tea.NewProgram(initialModel(cfg))
+
+	defer func() {
+		if r := recover(); r != nil {
+			// Reset the terminal before printing the trace, or the panic
+			// message itself gets swallowed by the alt screen.
+			p.ReleaseTerminal()
+			panic(r)
+		}
+	}()
+
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP)
+	defer signal.Stop(sigCh)
+
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		select {
+		case <-sigCh:
+			p.Quit()
+			select {
+			case <-done:
+			case <-time.After(killGracePeriod):
+				// The program didn't wind down on its own; force it so
+				// the terminal doesn't stay wrecked.
+				p.Kill()
+			}
+		case <-done:
+		}
+	}()
+
+	_, err = p.Run()
+	return err
+}
+
+func newTUICmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "tui",
+		Short: "Launch the interactive installer wizard (the default with no subcommand)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runTUI()
+		},
+	}
+}
+
+func newIngestCmd() *cobra.Command {
+	opts := ragcore.DefaultIngestOptions()
+
+	cmd := &cobra.Command{
+		Use:   "ingest <path|source|->",
+		Short: "Index a local documentation corpus into qdrant (a path, the name of a configured source, or \"-\" to read a single document from stdin)",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := args[0]
+			switch {
+			case path == "-":
+				if isTerminal(os.Stdin) {
+					return fmt.Errorf(`"-" reads documents from stdin, but stdin is a terminal`)
+				}
+				dir, err := ragcore.IngestStdinDir(os.Stdin)
+				if err != nil {
+					return err
+				}
+				defer os.RemoveAll(dir)
+				path = dir
+			default:
+				if src, ok := sourceByName(cfg, path); ok {
+					dir, err := ragcore.IngestSourceDir(src)
+					if err != nil {
+						return err
+					}
+					defer os.RemoveAll(dir)
+					path = dir
+				}
+			}
+			if !cmd.Flags().Changed("qdrant-url") {
+				opts.QdrantURL = cfg.VectorStore.Location
+			}
+			if !cmd.Flags().Changed("chunk-size") {
+				opts.ChunkSize = cfg.ChunkSize
+			}
+			return ragcore.Ingest(path, opts)
+		},
+	}
+
+	cmd.Flags().IntVar(&opts.Port, "port", opts.Port, "port to serve the corpus on while indexing")
+	cmd.Flags().StringVar(&opts.QdrantURL, "qdrant-url", opts.QdrantURL, "qdrant base URL to index into")
+	cmd.Flags().IntVar(&opts.ChunkSize, "chunk-size", opts.ChunkSize, "document chunk size in tokens")
+	return cmd
+}
+
+// sourceByName looks up a configured document source (cfg.Sources) by
+// name, matching the named-candidate pattern serviceByName uses for
+// services.
+func sourceByName(cfg *config.Config, name string) (config.Source, bool) {
+	for _, src := range cfg.Sources {
+		if src.Name == name {
+			return src, true
+		}
+	}
+	return config.Source{}, false
+}
+
+func newQueryCmd() *cobra.Command {
+	opts := ragcore.DefaultQueryOptions()
+
+	cmd := &cobra.Command{
+		Use:   "query [question]",
+		Short: "Run a single retrieval against the indexed documentation (reads stdin if no question is given)",
+		Args:  cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("model") {
+				opts.Model = cfg.Embedding.Model
+			}
+			if !cmd.Flags().Changed("qdrant-url") {
+				opts.QdrantURL = cfg.VectorStore.Location
+			}
+			question, err := queryQuestion(args)
+			if err != nil {
+				return err
+			}
+			results, err := ragcore.Query(question, opts)
+			if err != nil {
+				return err
+			}
+			if opts.Format == "json" {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(results)
+			}
+			for _, r := range results {
+				fmt.Printf("score=%.4f %v\n", r.Score, r.Payload)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.Model, "model", opts.Model, "embedding model to query with")
+	cmd.Flags().IntVar(&opts.TopK, "top-k", opts.TopK, "number of results to return")
+	cmd.Flags().StringVar(&opts.Format, "format", opts.Format, "output format: plain or json")
+	cmd.Flags().StringVar(&opts.QdrantURL, "qdrant-url", opts.QdrantURL, "qdrant base URL to search")
+	return cmd
+}
+
+func newServeCmd() *cobra.Command {
+	var port int
+
+	cmd := &cobra.Command{
+		Use:   "serve <path>",
+		Short: "Serve a local documentation corpus over HTTP for indexing",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return ragcore.Serve(args[0], port)
+		},
+	}
+
+	cmd.Flags().IntVar(&port, "port", 8000, "port to serve on")
+	return cmd
+}
+
+func newExportCmd() *cobra.Command {
+	opts := ragcore.DefaultExportOptions()
+	var out string
+
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Dump every indexed embedding and its payload as newline-delimited JSON",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !cmd.Flags().Changed("qdrant-url") {
+				opts.QdrantURL = cfg.VectorStore.Location
+			}
+
+			w := io.Writer(os.Stdout)
+			if out != "" {
+				f, err := os.Create(out)
+				if err != nil {
+					return err
+				}
+				defer f.Close()
+				w = f
+			}
+			return ragcore.Export(w, opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&out, "out", "", "file to write to (default: stdout)")
+	cmd.Flags().StringVar(&opts.QdrantURL, "qdrant-url", opts.QdrantURL, "qdrant base URL to export from")
+	cmd.Flags().IntVar(&opts.BatchSize, "batch-size", opts.BatchSize, "points fetched per qdrant scroll request")
+	return cmd
+}
+
+// SetupRootCommand wires up the flag-error behavior shared by the whole
+// command tree: a bad flag prints the failing command's usage instead of
+// cobra's default wall of text.
+func SetupRootCommand(cmd *cobra.Command) {
+	cmd.SetFlagErrorFunc(FlagErrorFunc)
+}
+
+// FlagErrorFunc reports a flag parsing error alongside the command's usage
+// line rather than cobra's full help dump.
+func FlagErrorFunc(cmd *cobra.Command, err error) error {
+	if err == nil {
+		return nil
+	}
+	return fmt.Errorf("%s\n\n%s", err, cmd.UsageString())
+}
+
+func newInstallCmd() *cobra.Command {
+	var components []string
+	var yes bool
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "install",
+		Short: "Install RAG platform components non-interactively",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			selected := selectedComponents(components)
+			plan, err := installer.BuildPlan(selected, installedComponents(), cfg)
+			if err != nil {
+				return err
+			}
+
+			// Pending also lists unselected and already-installed
+			// components (BuildPlan keeps them as satisfied steps), so
+			// only steps whose Check fails actually need installing.
+			var pending []installer.Step
+			for _, step := range plan.Pending() {
+				if !step.Check() {
+					pending = append(pending, step)
+				}
+			}
+			if len(pending) == 0 {
+				fmt.Println("Nothing to do; all selected components are already installed.")
+				return nil
+			}
+
+			if dryRun {
+				for _, step := range pending {
+					fmt.Printf("would install: %s\n", step.Name())
+				}
+				return nil
+			}
+
+			if !yes && !confirmf("About to install: %s. Proceed?", joinStepNames(pending)) {
+				fmt.Println("Aborted.")
+				return nil
+			}
+
+			for {
+				result, ok := plan.RunNext()
+				if !ok {
+					break
+				}
+				if result.Err != nil {
+					return fmt.Errorf("%s: %w", result.Name, result.Err)
+				}
+				fmt.Printf("✓ %s: %s\n", result.Name, result.Status)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringSliceVar(&components, "components", nil, "comma-separated components to install (default: all)")
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the confirmation prompt")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "print the steps that would run without applying them")
+	return cmd
+}
+
+func newDoctorCmd() *cobra.Command {
+	var asJSON bool
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Report the status of every managed component",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			status := doctorStatus()
+			if asJSON {
+				enc := json.NewEncoder(os.Stdout)
+				enc.SetIndent("", "  ")
+				return enc.Encode(status)
+			}
+			for _, name := range allComponents {
+				info := status[name]
+				fmt.Printf("%-12s installed=%-5v running=%v\n", name, info.Installed, info.Running)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&asJSON, "json", false, "print status as JSON")
+	return cmd
+}
+
+func newServiceCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "service",
+		Short: "Manage a single running service",
+	}
+	cmd.AddCommand(
+		newServiceActionCmd("start", "Restart a stopped or crashed service", services.Service.Restart),
+		newServiceActionCmd("stop", "Stop a running service", services.Service.Stop),
+	)
+	cmd.AddCommand(newServiceStatusCmd())
+	return cmd
+}
+
+func newServiceActionCmd(use, short string, action func(services.Service) error) *cobra.Command {
+	return &cobra.Command{
+		Use:   use + " <name>",
+		Short: short,
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			svc, err := serviceByName(args[0])
+			if err != nil {
+				return err
+			}
+			return action(svc)
+		},
+	}
+}
+
+func newServiceStatusCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "status <name>",
+		Short: "Show whether a service is installed and running",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			name := args[0]
+			if _, err := serviceByName(name); err != nil {
+				return err
+			}
+			info := doctorStatus()[statusKey(name)]
+			fmt.Printf("%-12s installed=%-5v running=%v\n", name, info.Installed, info.Running)
+			return nil
+		},
+	}
+}
+
+func newUninstallCmd() *cobra.Command {
+	var yes bool
+
+	cmd := &cobra.Command{
+		Use:   "uninstall",
+		Short: "Remove the qdrant container and the MCP server package",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if !yes && !confirmf("This will remove the qdrant container and the MCP server package. Proceed?") {
+				fmt.Println("Aborted.")
+				return nil
+			}
+			if err := installer.RemoveQdrantContainer(); err != nil {
+				fmt.Printf("qdrant: %v\n", err)
+			}
+			if err := installer.UninstallMCPServer(); err != nil {
+				fmt.Printf("mcp_server: %v\n", err)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVarP(&yes, "yes", "y", false, "skip the confirmation prompt")
+	return cmd
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Inspect the ragify configuration file",
+	}
+	cmd.AddCommand(newConfigValidateCmd())
+	return cmd
+}
+
+// newConfigValidateCmd lets a user catch a missing source glob or an
+// unrecognized LLM backend before it fails mid-ingest or mid-query, where
+// the error would be harder to trace back to the config file.
+func newConfigValidateCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "validate",
+		Short: "Check the config file for missing sources and bad model settings",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			path := config.ResolvePath(configPath)
+			if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+				fmt.Printf("no config file at %s; validating built-in defaults\n", path)
+			} else {
+				fmt.Printf("validating %s\n", path)
+			}
+
+			errs := cfg.Validate()
+			if len(errs) == 0 {
+				fmt.Println("config OK")
+				return nil
+			}
+			for _, e := range errs {
+				fmt.Printf("✗ %v\n", e)
+			}
+			return fmt.Errorf("%d config problem(s) found", len(errs))
+		},
+	}
+}
+
+// componentStatus is the JSON-friendly shape of serviceInfo; serviceInfo's
+// fields are unexported because they only ever feed the TUI's own
+// rendering, so `doctor --json` needs its own exported mirror.
+type componentStatus struct {
+	Installed bool `json:"installed"`
+	Running   bool `json:"running"`
+}
+
+func doctorStatus() map[string]componentStatus {
+	return map[string]componentStatus{
+		"docker":      {Installed: installer.CheckDocker(), Running: installer.CheckDockerRunning()},
+		"ollama":      {Installed: installer.CheckOllama(), Running: installer.CheckOllamaRunning()},
+		"qdrant":      {Installed: installer.CheckQdrantInstalled(), Running: installer.CheckQdrantRunning()},
+		"mcp_server":  {Installed: installer.CheckMCPServerInstalled(), Running: installer.CheckMCPServerRunning()},
+		"python_deps": {Installed: installer.CheckPythonDeps()},
+	}
+}
+
+func installedComponents() map[string]bool {
+	status := doctorStatus()
+	installed := make(map[string]bool, len(status))
+	for name, info := range status {
+		installed[name] = info.Installed
+	}
+	return installed
+}
+
+func selectedComponents(requested []string) map[string]bool {
+	if len(requested) == 0 {
+		requested = allComponents
+	}
+	selected := make(map[string]bool, len(requested))
+	for _, c := range requested {
+		selected[c] = true
+	}
+	return selected
+}
+
+func joinStepNames(steps []installer.Step) string {
+	names := make([]string, len(steps))
+	for i, s := range steps {
+		names[i] = s.Name()
+	}
+	return strings.Join(names, ", ")
+}
+
+// serviceByName looks up a manageable service by the name the doctor panel
+// shows it under, mirroring detectedServices' candidate list.
+func serviceByName(name string) (services.Service, error) {
+	candidates := map[string]services.Service{
+		"qdrant":             {Name: "qdrant", Kind: services.ContainerKind, Runtime: installer.GetContainerRuntime()},
+		"ollama":             {Name: "ollama", Kind: services.SystemdKind},
+		"mcp-server-ragdocs": {Name: "mcp-server-ragdocs", Kind: services.ProcessKind},
+		"mcp_server":         {Name: "mcp-server-ragdocs", Kind: services.ProcessKind},
+	}
+	svc, ok := candidates[name]
+	if !ok {
+		return services.Service{}, fmt.Errorf("unknown service %q (want one of: qdrant, ollama, mcp_server)", name)
+	}
+	return svc, nil
+}
+
+// statusKey maps a service subcommand's name argument onto the key
+// doctorStatus uses, since the service panel and the doctor checks name
+// the MCP server differently ("mcp-server-ragdocs" vs "mcp_server").
+func statusKey(name string) string {
+	if name == "mcp-server-ragdocs" {
+		return "mcp_server"
+	}
+	return name
+}
+
+// queryQuestion returns the question to run: the positional arg if one
+// was given, otherwise stdin's contents (so `echo "..." | ragify query`
+// works). It errors rather than blocking if stdin is an interactive
+// terminal with no piped input.
+func queryQuestion(args []string) (string, error) {
+	if len(args) > 0 {
+		return args[0], nil
+	}
+	if isTerminal(os.Stdin) {
+		return "", fmt.Errorf("a question is required: pass it as an argument or pipe it on stdin")
+	}
+	data, err := io.ReadAll(os.Stdin)
+	if err != nil {
+		return "", err
+	}
+	question := strings.TrimSpace(string(data))
+	if question == "" {
+		return "", fmt.Errorf("no question given: pass it as an argument or pipe it on stdin")
+	}
+	return question, nil
+}
+
+// isTerminal reports whether f is an interactive terminal rather than a
+// pipe or redirected file — the same character-device check isatty
+// performs, done here with the stdlib so the rest of the CLI doesn't need
+// to special-case Windows vs. Unix terminal detection.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
+
+func confirmf(format string, args ...interface{}) bool {
+	fmt.Printf(format+" [y/N] ", args...)
+	line, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	line = strings.TrimSpace(strings.ToLower(line))
+	return line == "y" || line == "yes"
+}
@@ -2,18 +2,19 @@ package main
 
 import (
 	"fmt"
-	"net/http"
 	"os"
-	"os/exec"
-	"runtime"
 	"strings"
-	"syscall"
 	"time"
 
 	"github.com/charmbracelet/bubbles/progress"
 	"github.com/charmbracelet/bubbles/spinner"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
+
+	"github.com/strawberry-code/ragify/internal/config"
+	"github.com/strawberry-code/ragify/internal/installer"
+	"github.com/strawberry-code/ragify/internal/services"
+	"github.com/strawberry-code/ragify/internal/tui/panels"
 )
 
 // Styles
@@ -83,6 +84,11 @@ type model struct {
 	installLogs    []string
 	serviceStatus  map[string]serviceInfo
 	doctorChecking bool
+	servicePanel   panels.Model
+	installPlan    *installer.Plan
+	lastFailedStep string
+	runtimeInfo    string
+	cfg            *config.Config
 }
 
 type serviceInfo struct {
@@ -106,13 +112,18 @@ type installComplete struct{}
 
 type doctorCheckComplete struct {
 	services map[string]serviceInfo
+	runtime  string
 }
 
-func initialModel() model {
+func initialModel(cfg *config.Config) model {
 	s := spinner.New()
 	s.Spinner = spinner.Dot
 	s.Style = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4"))
 
+	if cfg == nil {
+		cfg = config.Default()
+	}
+
 	return model{
 		currentScreen: mainMenuScreen,
 		cursor:        0,
@@ -131,6 +142,7 @@ func initialModel() model {
 		installLogs:    []string{},
 		serviceStatus:  make(map[string]serviceInfo),
 		doctorChecking: false,
+		cfg:            cfg,
 	}
 }
 
@@ -139,6 +151,21 @@ func (m model) Init() tea.Cmd {
 }
 
 func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if km, ok := msg.(tea.KeyMsg); ok && m.currentScreen == doctorServiceScreen {
+		switch km.String() {
+		case "ctrl+c", "q":
+			return m, tea.Quit
+		case "m":
+			m.currentScreen = mainMenuScreen
+			m.cursor = 0
+			return m, nil
+		default:
+			var cmd tea.Cmd
+			m.servicePanel, cmd = m.servicePanel.Update(km)
+			return m, cmd
+		}
+	}
+
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
@@ -146,11 +173,6 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			if m.currentScreen != installScreen {
 				return m, tea.Quit
 			}
-		case "m":
-			if m.currentScreen == doctorServiceScreen {
-				m.currentScreen = mainMenuScreen
-				m.cursor = 0
-			}
 		case "enter":
 			return m.handleEnter()
 		case "up", "k":
@@ -164,6 +186,8 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case " ":
 			return m.handleSpace()
+		case "r":
+			return m.handleRetry()
 		}
 
 	case tea.WindowSizeMsg:
@@ -190,9 +214,11 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		m.installStatus[msg.component] = msg.status
 		if msg.err != nil {
 			m.installLogs = append(m.installLogs, fmt.Sprintf("❌ %s: %v", msg.component, msg.err))
-		} else {
-			m.installLogs = append(m.installLogs, fmt.Sprintf("✓ %s: %s", msg.component, msg.status))
+			m.lastFailedStep = msg.component
+			return m, nil // pause here; wait for 'r' to retry just this step
 		}
+		m.installLogs = append(m.installLogs, fmt.Sprintf("✓ %s: %s", msg.component, msg.status))
+		m.lastFailedStep = ""
 		return m, m.runNextInstall()
 
 	case installComplete:
@@ -201,9 +227,23 @@ func (m model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case doctorCheckComplete:
 		m.serviceStatus = msg.services
+		m.runtimeInfo = msg.runtime
 		m.doctorChecking = false
 		m.currentScreen = doctorServiceScreen
 		m.cursor = 0
+		m.servicePanel = panels.New(detectedServices(msg.services))
+		return m, m.servicePanel.Init()
+
+	default:
+		// Everything the panel's own Init/Update commands produce
+		// (tabContentMsg, logLineMsg, actionDoneMsg) arrives here rather
+		// than as a tea.KeyMsg, since those types are unexported to
+		// panels and can't get their own case above.
+		if m.currentScreen == doctorServiceScreen {
+			var cmd tea.Cmd
+			m.servicePanel, cmd = m.servicePanel.Update(msg)
+			return m, cmd
+		}
 	}
 
 	return m, nil
@@ -226,6 +266,12 @@ func (m model) handleEnter() (tea.Model, tea.Cmd) {
 		m.currentScreen = checkScreen
 		return m, m.runChecks()
 	case selectScreen:
+		plan, err := installer.BuildPlan(m.selected, m.checks, m.cfg)
+		if err != nil {
+			m.err = err
+			return m, nil
+		}
+		m.installPlan = plan
 		m.currentScreen = installScreen
 		m.installing = true
 		return m, tea.Batch(m.spinner.Tick, m.runInstallation())
@@ -239,11 +285,6 @@ func (m model) handleEnter() (tea.Model, tea.Cmd) {
 		return m, nil
 	case completeScreen:
 		return m, tea.Quit
-	case doctorServiceScreen:
-		// Continue/activate selected services logic here
-		m.currentScreen = mainMenuScreen
-		m.cursor = 0
-		return m, m.activateSelectedServices()
 	}
 	return m, nil
 }
@@ -256,16 +297,24 @@ func (m model) handleSpace() (tea.Model, tea.Cmd) {
 			m.selected[component] = !m.selected[component]
 		}
 	}
-	if m.currentScreen == doctorServiceScreen {
-		services := []string{"docker", "ollama", "qdrant", "mcp_server"}
-		if m.cursor < len(services) {
-			service := services[m.cursor]
-			info := m.serviceStatus[service]
-			info.enabled = !info.enabled
-			m.serviceStatus[service] = info
+	return m, nil
+}
+
+// handleRetry re-attempts whatever step last failed, without restarting
+// the rest of the install plan.
+func (m model) handleRetry() (tea.Model, tea.Cmd) {
+	if m.currentScreen != installScreen || m.lastFailedStep == "" || m.installPlan == nil {
+		return m, nil
+	}
+	name := m.lastFailedStep
+	plan := m.installPlan
+	return m, func() tea.Msg {
+		result, err := plan.Retry(name)
+		if err != nil {
+			return installStepMsg{component: name, status: "failed", err: err}
 		}
+		return installStepMsg{component: result.Name, status: result.Status, err: result.Err}
 	}
-	return m, nil
 }
 
 func (m model) getMaxCursor() int {
@@ -276,8 +325,6 @@ func (m model) getMaxCursor() int {
 		return 4
 	case configScreen:
 		return 3
-	case doctorServiceScreen:
-		return 3
 	default:
 		return 0
 	}
@@ -285,7 +332,7 @@ func (m model) getMaxCursor() int {
 
 func (m model) runChecks() tea.Cmd {
 	return func() tea.Msg {
-		return checkResult{component: "docker", installed: checkDocker()}
+		return checkResult{component: "docker", installed: installer.CheckDocker()}
 	}
 }
 
@@ -293,19 +340,19 @@ func (m model) runNextCheck() tea.Cmd {
 	return func() tea.Msg {
 		switch len(m.checks) {
 		case 1:
-			return checkResult{component: "ollama", installed: checkOllama()}
+			return checkResult{component: "ollama", installed: installer.CheckOllama()}
 		case 2:
-			return checkResult{component: "nodejs", installed: checkNodeJS()}
+			return checkResult{component: "nodejs", installed: installer.CheckNodeJS()}
 		case 3:
-			return checkResult{component: "python", installed: checkPython()}
+			return checkResult{component: "python", installed: installer.CheckPython()}
 		case 4:
-			return checkResult{component: "python_deps", installed: checkPythonDeps()}
+			return checkResult{component: "python_deps", installed: installer.CheckPythonDeps()}
 		case 5:
-			return checkResult{component: "qdrant", installed: checkQdrantInstalled()}
+			return checkResult{component: "qdrant", installed: installer.CheckQdrantInstalled()}
 		case 6:
-			return checkResult{component: "mcp_server", installed: checkMCPServerInstalled()}
+			return checkResult{component: "mcp_server", installed: installer.CheckMCPServerInstalled()}
 		case 7:
-			return checkResult{component: "disk", installed: checkDiskSpace()}
+			return checkResult{component: "disk", installed: installer.CheckDiskSpace()}
 		}
 		return nil
 	}
@@ -313,379 +360,81 @@ func (m model) runNextCheck() tea.Cmd {
 
 func (m model) runDoctorChecks() tea.Cmd {
 	return func() tea.Msg {
-		services := make(map[string]serviceInfo)
+		result := make(map[string]serviceInfo)
 
 		// Check Docker
-		services["docker"] = serviceInfo{
-			installed: checkDocker(),
-			running:   checkDockerRunning(),
+		result["docker"] = serviceInfo{
+			installed: installer.CheckDocker(),
+			running:   installer.CheckDockerRunning(),
 			enabled:   false,
 		}
 
 		// Check Ollama
-		services["ollama"] = serviceInfo{
-			installed: checkOllama(),
-			running:   checkOllamaRunning(),
+		result["ollama"] = serviceInfo{
+			installed: installer.CheckOllama(),
+			running:   installer.CheckOllamaRunning(),
 			enabled:   false,
 		}
 
 		// Check Qdrant
-		services["qdrant"] = serviceInfo{
-			installed: checkQdrantInstalled(),
-			running:   checkQdrantRunning(),
+		result["qdrant"] = serviceInfo{
+			installed: installer.CheckQdrantInstalled(),
+			running:   installer.CheckQdrantRunning(),
 			enabled:   false,
 		}
 
 		// Check MCP Server
-		services["mcp_server"] = serviceInfo{
-			installed: checkMCPServerInstalled(),
-			running:   checkMCPServerRunning(),
+		result["mcp_server"] = serviceInfo{
+			installed: installer.CheckMCPServerInstalled(),
+			running:   installer.CheckMCPServerRunning(),
 			enabled:   false,
 		}
 
-		return doctorCheckComplete{services: services}
+		return doctorCheckComplete{services: result, runtime: installer.RuntimeInfo()}
 	}
 }
 
-func (m model) activateSelectedServices() tea.Cmd {
-	return func() tea.Msg {
-		runtime := getContainerRuntime()
-		for service, info := range m.serviceStatus {
-			if info.enabled && info.installed && !info.running {
-				switch service {
-				case "docker":
-					if runtime == "docker" {
-						exec.Command("sudo", "systemctl", "start", "docker").Run()
-					} else if runtime == "podman" {
-						exec.Command("sudo", "systemctl", "start", "podman").Run()
-					}
-				case "ollama":
-					exec.Command("systemctl", "start", "ollama").Run()
-				case "qdrant":
-					installQdrant()
-				}
-			}
-		}
-		return nil
-	}
-}
+// detectedServices turns the doctor's install/running snapshot into the
+// set of services the panel can manage, skipping anything not installed.
+func detectedServices(status map[string]serviceInfo) []services.Service {
+	runtime := installer.GetContainerRuntime()
 
-var installQueue []string
+	candidates := []services.Service{
+		{Name: "qdrant", Kind: services.ContainerKind, Runtime: runtime},
+		{Name: "ollama", Kind: services.SystemdKind},
+		{Name: "mcp-server-ragdocs", Kind: services.ProcessKind},
+	}
 
-func (m model) runInstallation() tea.Cmd {
-	return func() tea.Msg {
-		installQueue = []string{}
-		for component, selected := range m.selected {
-			if selected && !m.checks[component] {
-				installQueue = append(installQueue, component)
-			}
+	var out []services.Service
+	for _, svc := range candidates {
+		key := svc.Name
+		if key == "mcp-server-ragdocs" {
+			key = "mcp_server"
 		}
-		if len(installQueue) == 0 {
-			return installComplete{}
+		if status[key].installed {
+			out = append(out, svc)
 		}
-		return m.installNext()()
 	}
+	return out
 }
 
-func (m model) runNextInstall() tea.Cmd {
-	return func() tea.Msg {
-		if len(installQueue) > 0 {
-			return m.installNext()()
-		}
-		return installComplete{}
-	}
+// runInstallation and runNextInstall both just pull the next pending step
+// off the install plan; they're kept as separate methods because they're
+// wired up from different places (entering installScreen vs. finishing a
+// step) even though runInstallation just delegates to runNextInstall.
+func (m model) runInstallation() tea.Cmd {
+	return m.runNextInstall()
 }
 
-func (m model) installNext() tea.Cmd {
+func (m model) runNextInstall() tea.Cmd {
+	plan := m.installPlan
 	return func() tea.Msg {
-		if len(installQueue) == 0 {
+		result, ok := plan.RunNext()
+		if !ok {
 			return installComplete{}
 		}
-
-		component := installQueue[0]
-		installQueue = installQueue[1:]
-
-		var err error
-		var status string
-
-		switch component {
-		case "docker":
-			status, err = installDocker()
-		case "ollama":
-			status, err = installOllama()
-		case "qdrant":
-			status, err = installQdrant()
-		case "mcp_server":
-			status, err = installMCPServer()
-		case "python_deps":
-			status, err = installPythonDeps()
-		}
-
-		return installStepMsg{component: component, status: status, err: err}
-	}
-}
-
-// Global variable to store preferred container runtime
-var containerRuntime string
-
-// Get container runtime (podman preferred, docker as fallback)
-func getContainerRuntime() string {
-	if containerRuntime != "" {
-		return containerRuntime
-	}
-	
-	// Try podman first
-	if exec.Command("podman", "--version").Run() == nil {
-		containerRuntime = "podman"
-		return containerRuntime
-	}
-	
-	// Fallback to docker
-	if exec.Command("docker", "--version").Run() == nil {
-		containerRuntime = "docker"
-		return containerRuntime
-	}
-	
-	return ""
-}
-
-// Check functions
-func checkDocker() bool {
-	return getContainerRuntime() != ""
-}
-
-func checkDockerRunning() bool {
-	runtime := getContainerRuntime()
-	if runtime == "" {
-		return false
-	}
-	cmd := exec.Command(runtime, "ps")
-	return cmd.Run() == nil
-}
-
-func checkOllama() bool {
-	return checkOllamaRunning()
-}
-
-func checkOllamaRunning() bool {
-	resp, err := http.Get("http://localhost:11434/api/tags")
-	if err != nil {
-		return false
-	}
-	defer resp.Body.Close()
-	return resp.StatusCode == 200
-}
-
-func checkQdrantInstalled() bool {
-	runtime := getContainerRuntime()
-	if runtime == "" {
-		return false
-	}
-	// Check if qdrant/qdrant image exists
-	cmd := exec.Command(runtime, "images", "-q", "qdrant/qdrant")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	// Also check docker.io/qdrant/qdrant for podman
-	if len(strings.TrimSpace(string(out))) > 0 {
-		return true
-	}
-	cmd = exec.Command(runtime, "images", "-q", "docker.io/qdrant/qdrant")
-	out, err = cmd.Output()
-	if err != nil {
-		return false
-	}
-	return len(strings.TrimSpace(string(out))) > 0
-}
-
-func checkQdrantRunning() bool {
-	resp, err := http.Get("http://localhost:6333/")
-	if err != nil {
-		return false
-	}
-	defer resp.Body.Close()
-	return resp.StatusCode == 200
-}
-
-func checkMCPServerInstalled() bool {
-	// Check npm global list with depth 0
-	cmd := exec.Command("npm", "list", "-g", "--depth=0")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	return strings.Contains(string(out), "@qpd-v/mcp-server-ragdocs")
-}
-
-func checkMCPServerRunning() bool {
-	cmd := exec.Command("pgrep", "-f", "mcp-server-ragdocs")
-	return cmd.Run() == nil
-}
-
-func checkNodeJS() bool {
-	cmd := exec.Command("node", "--version")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	version := strings.TrimSpace(string(out))
-	return strings.HasPrefix(version, "v")
-}
-
-func checkPython() bool {
-	cmd := exec.Command("python3", "--version")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	version := strings.TrimSpace(string(out))
-	
-	// Check for Python 3.10+
-	if !strings.Contains(version, "Python 3.") {
-		return false
-	}
-	
-	// Extract version number (e.g., "Python 3.10.5" -> "3.10")
-	parts := strings.Fields(version)
-	if len(parts) < 2 {
-		return false
-	}
-	versionNum := parts[1]
-	versionParts := strings.Split(versionNum, ".")
-	if len(versionParts) < 2 {
-		return false
-	}
-	
-	// Check major version (must be 3)
-	if versionParts[0] != "3" {
-		return false
-	}
-	
-	// Check minor version (must be >= 10 for Chonkie)
-	minorVersion := 0
-	fmt.Sscanf(versionParts[1], "%d", &minorVersion)
-	return minorVersion >= 10
-}
-
-func checkPythonDeps() bool {
-	// Check if all required packages are installed
-	cmd := exec.Command("pip3", "list")
-	out, err := cmd.Output()
-	if err != nil {
-		return false
-	}
-	output := string(out)
-	
-	requiredPackages := []string{
-		"requests",
-		"beautifulsoup4",
-		"chonkie",
-		"semchunk",
-		"tiktoken",
-	}
-	
-	for _, pkg := range requiredPackages {
-		if !strings.Contains(output, pkg) {
-			return false
-		}
-	}
-	
-	return true
-}
-
-func checkDiskSpace() bool {
-	var stat syscall.Statfs_t
-	err := syscall.Statfs("/", &stat)
-	if err != nil {
-		return false
-	}
-	available := stat.Bavail * uint64(stat.Bsize)
-	fiveGB := uint64(5 * 1024 * 1024 * 1024)
-	return available > fiveGB
-}
-
-// Install functions
-func installDocker() (string, error) {
-	goos := runtime.GOOS
-	switch goos {
-	case "darwin":
-		return "Please install Docker Desktop from docker.com/products/docker-desktop", fmt.Errorf("manual installation required")
-	case "linux":
-		cmd := exec.Command("sh", "-c", "curl -fsSL https://get.docker.com | sh")
-		err := cmd.Run()
-		if err != nil {
-			return "Failed", err
-		}
-		return "Installed via get.docker.com", nil
-	default:
-		return "Unsupported OS", fmt.Errorf("unsupported operating system: %s", goos)
-	}
-}
-
-func installOllama() (string, error) {
-	goos := runtime.GOOS
-	switch goos {
-	case "darwin":
-		return "Please download from ollama.ai/download", fmt.Errorf("manual installation required")
-	case "linux":
-		cmd := exec.Command("sh", "-c", "curl -fsSL https://ollama.ai/install.sh | sh")
-		err := cmd.Run()
-		if err != nil {
-			return "Failed", err
-		}
-		// Pull the embedding model
-		pullCmd := exec.Command("ollama", "pull", "nomic-embed-text")
-		if err := pullCmd.Run(); err != nil {
-			return "Installed but model pull failed", err
-		}
-		return "Installed with nomic-embed-text", nil
-	default:
-		return "Unsupported OS", fmt.Errorf("unsupported operating system: %s", goos)
-	}
-}
-
-func installQdrant() (string, error) {
-	runtime := getContainerRuntime()
-	if runtime == "" {
-		return "No container runtime found", fmt.Errorf("neither podman nor docker is available")
+		return installStepMsg{component: result.Name, status: result.Status, err: result.Err}
 	}
-	
-	cmd := exec.Command(runtime, "run", "-d",
-		"--name", "qdrant",
-		"-p", "6333:6333",
-		"-p", "6334:6334",
-		"-v", "./qdrant_storage:/qdrant/storage",
-		"qdrant/qdrant:latest")
-
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		if strings.Contains(string(output), "already in use") {
-			return "Already running", nil
-		}
-		return "Failed", err
-	}
-
-	time.Sleep(2 * time.Second)
-	return fmt.Sprintf("Started in %s", runtime), nil
-}
-
-func installMCPServer() (string, error) {
-	cmd := exec.Command("npm", "install", "-g", "@qpd-v/mcp-server-ragdocs")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "Failed: " + string(output), err
-	}
-	return "Installed globally via npm", nil
-}
-
-func installPythonDeps() (string, error) {
-	cmd := exec.Command("pip3", "install", "-r", "../requirements.txt")
-	output, err := cmd.CombinedOutput()
-	if err != nil {
-		return "Failed: " + string(output), err
-	}
-	return "Installed requests, beautifulsoup4, chonkie, semchunk, tiktoken", nil
 }
 
 func (m model) View() string {
@@ -743,14 +492,15 @@ func (m model) welcomeView() string {
 	s := "\n"
 	s += titleStyle.Render("🚀 Self-Hosted RAG Platform Installer") + "\n\n"
 	s += subtitleStyle.Render("Welcome to the interactive installation wizard!") + "\n\n"
-	s += lipgloss.NewStyle().MarginLeft(2).Render(
+	s += lipgloss.NewStyle().MarginLeft(2).Render(fmt.Sprintf(
 		"This installer will help you set up:\n"+
-			"  • Qdrant (Vector Database)\n"+
-			"  • Ollama (Embeddings Model)\n"+
-			"  • MCP Server (Query Interface)\n"+
+			"  • Qdrant (Vector Database: %s)\n"+
+			"  • Ollama (Embeddings Model: %s)\n"+
+			"  • MCP Server (Query Interface, LLM: %s/%s)\n"+
 			"  • Python Dependencies\n"+
 			"  • Optional: Client Configuration\n",
-	) + "\n\n"
+		m.cfg.VectorStore.Location, m.cfg.Embedding.Model, m.cfg.LLM.Backend, m.cfg.LLM.Model,
+	)) + "\n\n"
 	s += helpStyle.Render("Press Enter to start • q to quit") + "\n"
 	return s
 }
@@ -765,49 +515,10 @@ func (m model) doctorView() string {
 
 func (m model) doctorServiceView() string {
 	s := "\n"
-	s += titleStyle.Render("🩺 Service Status") + "\n\n"
-
-	services := []struct {
-		name string
-		key  string
-	}{
-		{"Docker", "docker"},
-		{"Ollama", "ollama"},
-		{"Qdrant", "qdrant"},
-		{"MCP Server", "mcp_server"},
-	}
-
-	for i, svc := range services {
-		cursor := " "
-		if m.cursor == i {
-			cursor = ">"
-		}
-
-		info := m.serviceStatus[svc.key]
-
-		checkbox := "☐"
-		if info.enabled {
-			checkbox = checkboxStyle.Render("☑")
-		}
-
-		installedStatus := errorStyle.Render("✗ Not installed")
-		if info.installed {
-			installedStatus = successStyle.Render("✓ Installed")
-		}
-
-		runningStatus := errorStyle.Render("✗ Not running")
-		if info.running {
-			runningStatus = successStyle.Render("✓ Running")
-		}
-
-		line := fmt.Sprintf("%s %s %-15s │ %s │ %s", cursor, checkbox, svc.name, installedStatus, runningStatus)
-		if m.cursor == i {
-			line = selectedStyle.Render(line)
-		}
-		s += lipgloss.NewStyle().MarginLeft(2).Render(line) + "\n"
-	}
-
-	s += "\n" + helpStyle.Render("↑/↓: navigate • space: toggle service • enter: activate selected • m: main menu • q: quit") + "\n"
+	s += titleStyle.Render("🩺 Service Panel") + "\n\n"
+	s += subtitleStyle.Render("Container runtime: "+m.runtimeInfo) + "\n\n"
+	s += m.servicePanel.View()
+	s += helpStyle.Render("m: main menu • q: quit") + "\n"
 	return s
 }
 
@@ -886,25 +597,25 @@ func (m model) selectView() string {
 		
 		// Check if running (only for services)
 		if comp.key == "docker" {
-			if checkDockerRunning() {
+			if installer.CheckDockerRunning() {
 				statusParts = append(statusParts, successStyle.Render("✓ Running"))
 			} else {
 				statusParts = append(statusParts, errorStyle.Render("✗ Not running"))
 			}
 		} else if comp.key == "ollama" {
-			if checkOllamaRunning() {
+			if installer.CheckOllamaRunning() {
 				statusParts = append(statusParts, successStyle.Render("✓ Running"))
 			} else {
 				statusParts = append(statusParts, errorStyle.Render("✗ Not running"))
 			}
 		} else if comp.key == "qdrant" {
-			if checkQdrantRunning() {
+			if installer.CheckQdrantRunning() {
 				statusParts = append(statusParts, successStyle.Render("✓ Running"))
 			} else {
 				statusParts = append(statusParts, errorStyle.Render("✗ Not running"))
 			}
 		} else if comp.key == "mcp_server" {
-			if checkMCPServerRunning() {
+			if installer.CheckMCPServerRunning() {
 				statusParts = append(statusParts, successStyle.Render("✓ Running"))
 			} else {
 				statusParts = append(statusParts, errorStyle.Render("✗ Not running"))
@@ -935,7 +646,21 @@ func (m model) installView() string {
 		s += lipgloss.NewStyle().MarginLeft(2).Render(m.spinner.View() + " Starting installation...") + "\n"
 	}
 
-	s += "\n" + helpStyle.Render("Please wait... (this may take a few minutes)") + "\n"
+	if m.installPlan != nil {
+		if pending := m.installPlan.Pending(); len(pending) > 0 {
+			names := make([]string, len(pending))
+			for i, step := range pending {
+				names[i] = step.Name()
+			}
+			s += "\n" + helpStyle.Render("Remaining: "+strings.Join(names, " → ")) + "\n"
+		}
+	}
+
+	if m.lastFailedStep != "" {
+		s += "\n" + warningStyle.Render(fmt.Sprintf("%s failed — press r to retry", m.lastFailedStep)) + "\n"
+	} else {
+		s += "\n" + helpStyle.Render("Please wait... (this may take a few minutes)") + "\n"
+	}
 	return s
 }
 
@@ -992,9 +717,8 @@ func (m model) completeView() string {
 }
 
 func main() {
-	p := tea.NewProgram(initialModel())
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error: %v\n", err)
+	if err := newRootCmd().Execute(); err != nil {
+		fmt.Println(err)
 		os.Exit(1)
 	}
 }
@@ -0,0 +1,150 @@
+// Package config loads the optional YAML/TOML manifest that describes a
+// user's RAG setup — embedding model, LLM backend, chunk size, vector
+// store location, and named document sources — so both the TUI and the
+// ragify CLI start from the user's actual configuration instead of
+// hardcoded defaults.
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the typed shape of ~/.config/ragify/config.yaml (or the TOML
+// equivalent).
+type Config struct {
+	Embedding   EmbeddingConfig   `yaml:"embedding" toml:"embedding"`
+	LLM         LLMConfig         `yaml:"llm" toml:"llm"`
+	ChunkSize   int               `yaml:"chunk_size" toml:"chunk_size"`
+	VectorStore VectorStoreConfig `yaml:"vector_store" toml:"vector_store"`
+	Sources     []Source          `yaml:"sources" toml:"sources"`
+}
+
+// EmbeddingConfig names the model used to embed documents and queries.
+type EmbeddingConfig struct {
+	Model string `yaml:"model" toml:"model"`
+}
+
+// LLMConfig names the backend and model used for generation.
+type LLMConfig struct {
+	Backend string `yaml:"backend" toml:"backend"` // "ollama", "openai", or "local"
+	Model   string `yaml:"model" toml:"model"`
+}
+
+// VectorStoreConfig locates the qdrant instance documents are indexed
+// into and queried from.
+type VectorStoreConfig struct {
+	Location string `yaml:"location" toml:"location"`
+}
+
+// Source is one named corpus of documents, matched by a glob.
+type Source struct {
+	Name string `yaml:"name" toml:"name"`
+	Glob string `yaml:"glob" toml:"glob"`
+}
+
+// validBackends are the LLM backends Validate accepts.
+var validBackends = map[string]bool{"ollama": true, "openai": true, "local": true}
+
+// Default returns the configuration ragify runs with when no config file
+// is present, matching the model InstallOllama pulls and the qdrant port
+// InstallQdrant exposes.
+func Default() *Config {
+	return &Config{
+		Embedding:   EmbeddingConfig{Model: "nomic-embed-text"},
+		LLM:         LLMConfig{Backend: "ollama", Model: "llama3"},
+		ChunkSize:   512,
+		VectorStore: VectorStoreConfig{Location: "http://localhost:6333"},
+	}
+}
+
+// DefaultPath returns ~/.config/ragify/config.yaml, or "" if the home
+// directory can't be determined.
+func DefaultPath() string {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "ragify", "config.yaml")
+}
+
+// ResolvePath picks the config file to use: flagValue if non-empty
+// (the --config flag), else $RAGIFY_CONFIG, else DefaultPath.
+func ResolvePath(flagValue string) string {
+	if flagValue != "" {
+		return flagValue
+	}
+	if env := os.Getenv("RAGIFY_CONFIG"); env != "" {
+		return env
+	}
+	return DefaultPath()
+}
+
+// Load reads and parses the config file at path. A missing file is not an
+// error — ragify works out of the box against Default() — but a present,
+// malformed one is.
+func Load(path string) (*Config, error) {
+	cfg := Default()
+	if path == "" {
+		return cfg, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config %s: %w", path, err)
+	}
+
+	if strings.EqualFold(filepath.Ext(path), ".toml") {
+		if err := toml.Unmarshal(data, cfg); err != nil {
+			return nil, fmt.Errorf("parsing toml config %s: %w", path, err)
+		}
+	} else if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing yaml config %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// Validate reports problems that would otherwise only surface mid-run:
+// an unrecognized LLM backend, a nonsensical chunk size, or a source
+// whose glob doesn't match anything on disk.
+func (c *Config) Validate() []error {
+	var errs []error
+
+	if c.Embedding.Model == "" {
+		errs = append(errs, fmt.Errorf("embedding.model is required"))
+	}
+	if !validBackends[c.LLM.Backend] {
+		errs = append(errs, fmt.Errorf("llm.backend %q is not one of ollama, openai, local", c.LLM.Backend))
+	}
+	if c.ChunkSize <= 0 {
+		errs = append(errs, fmt.Errorf("chunk_size must be positive, got %d", c.ChunkSize))
+	}
+
+	for _, src := range c.Sources {
+		if src.Name == "" {
+			errs = append(errs, fmt.Errorf("a source is missing a name"))
+			continue
+		}
+		if src.Glob == "" {
+			errs = append(errs, fmt.Errorf("source %q has no glob", src.Name))
+			continue
+		}
+		matches, err := filepath.Glob(src.Glob)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("source %q: invalid glob %q: %w", src.Name, src.Glob, err))
+		} else if len(matches) == 0 {
+			errs = append(errs, fmt.Errorf("source %q: glob %q matched no files", src.Name, src.Glob))
+		}
+	}
+
+	return errs
+}
@@ -0,0 +1,88 @@
+// Package cruntime abstracts over the container runtimes the installer can
+// drive (docker, podman, nerdctl/containerd) behind a single Runtime
+// interface, the same way internal/distro abstracts over package
+// managers. Detect picks whichever runtime is actually on the host.
+package cruntime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// RunSpec describes a container to start. Ports and Volumes use the
+// familiar "host:container" shorthand so callers don't need to know each
+// runtime's own flag syntax.
+type RunSpec struct {
+	Name    string
+	Image   string
+	Ports   []string
+	Volumes []string
+	Detach  bool
+}
+
+// ContainerInfo is a runtime-agnostic snapshot of a container's state.
+type ContainerInfo struct {
+	ID      string
+	Name    string
+	Image   string
+	Running bool
+}
+
+// Runtime is one container engine the installer knows how to drive.
+// Stop, Logs, and Inspect accept either a container name or ID, matching
+// how docker/podman/nerdctl's own CLIs resolve references.
+type Runtime interface {
+	Name() string
+	Version() (string, error)
+	ImageExists(ref string) (bool, error)
+	Pull(ref string) error
+	Run(spec RunSpec) (containerID string, err error)
+	Stop(ref string) error
+	Logs(ref string, follow bool) (io.ReadCloser, error)
+	Inspect(ref string) (ContainerInfo, error)
+}
+
+// cached holds the previously detected runtime, mirroring distro.Detect's
+// caching so repeated checks within one run don't re-probe the host.
+var cached Runtime
+
+// Detect returns the preferred runtime: podman first, then docker, then
+// nerdctl — the same precedence the installer has always given podman
+// over docker, extended to cover containerd-based hosts.
+func Detect() (Runtime, error) {
+	if cached != nil {
+		return cached, nil
+	}
+	rt, err := detect(execRunner{})
+	if err != nil {
+		return nil, err
+	}
+	cached = rt
+	return rt, nil
+}
+
+func detect(r Runner) (Runtime, error) {
+	for _, rt := range []Runtime{newPodman(r), newDocker(r), newNerdctl(r)} {
+		if _, err := rt.Version(); err == nil {
+			return rt, nil
+		}
+	}
+	return nil, fmt.Errorf("no container runtime found (looked for podman, docker, nerdctl)")
+}
+
+// parseInspect splits the pipe-delimited output every Inspect
+// implementation here asks for via --format, since docker, podman, and
+// nerdctl all expose the same Go-template fields.
+func parseInspect(out string) (ContainerInfo, error) {
+	fields := strings.Split(strings.TrimSpace(out), "|")
+	if len(fields) != 4 {
+		return ContainerInfo{}, fmt.Errorf("unexpected inspect output: %q", out)
+	}
+	return ContainerInfo{
+		ID:      fields[0],
+		Name:    strings.TrimPrefix(fields[1], "/"),
+		Image:   fields[2],
+		Running: fields[3] == "true",
+	}, nil
+}
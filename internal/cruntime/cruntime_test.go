@@ -0,0 +1,180 @@
+package cruntime
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// fakeRunner records every invocation instead of shelling out, the same
+// mocking approach plan_test.go uses fakeStep for.
+type fakeRunner struct {
+	calls   [][]string
+	outputs map[string]string
+	errs    map[string]error
+}
+
+func (f *fakeRunner) Output(name string, args ...string) (string, error) {
+	call := append([]string{name}, args...)
+	key := strings.Join(call, " ")
+	f.calls = append(f.calls, call)
+	if err, ok := f.errs[key]; ok {
+		return err.Error(), err
+	}
+	return f.outputs[key], nil
+}
+
+func (f *fakeRunner) Piped(name string, args ...string) (io.ReadCloser, error) {
+	call := append([]string{name}, args...)
+	key := strings.Join(call, " ")
+	f.calls = append(f.calls, call)
+	if err, ok := f.errs[key]; ok {
+		return nil, err
+	}
+	return io.NopCloser(strings.NewReader(f.outputs[key])), nil
+}
+
+func (f *fakeRunner) lastCall() string {
+	if len(f.calls) == 0 {
+		return ""
+	}
+	return strings.Join(f.calls[len(f.calls)-1], " ")
+}
+
+func TestDetectPrefersPodmanOverDocker(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"podman version --format {{.Client.Version}}": "4.3.1",
+		"docker version --format {{.Client.Version}}":  "24.0.2",
+	}}
+	rt, err := detect(r)
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if rt.Name() != "podman" {
+		t.Errorf("Name() = %q, want podman", rt.Name())
+	}
+}
+
+func TestDetectFallsBackToDockerWhenPodmanMissing(t *testing.T) {
+	r := &fakeRunner{
+		errs: map[string]error{
+			"podman version --format {{.Client.Version}}": errors.New("exec: \"podman\": executable file not found in $PATH"),
+		},
+		outputs: map[string]string{
+			"docker version --format {{.Client.Version}}": "24.0.2",
+		},
+	}
+	rt, err := detect(r)
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if rt.Name() != "docker" {
+		t.Errorf("Name() = %q, want docker", rt.Name())
+	}
+}
+
+func TestDetectFallsBackToNerdctl(t *testing.T) {
+	r := &fakeRunner{
+		errs: map[string]error{
+			"podman version --format {{.Client.Version}}": errors.New("not found"),
+			"docker version --format {{.Client.Version}}": errors.New("not found"),
+		},
+		outputs: map[string]string{
+			"nerdctl version --format {{.Client.Version}}": "1.5.0",
+		},
+	}
+	rt, err := detect(r)
+	if err != nil {
+		t.Fatalf("detect: %v", err)
+	}
+	if rt.Name() != "nerdctl" {
+		t.Errorf("Name() = %q, want nerdctl", rt.Name())
+	}
+}
+
+func TestDetectReturnsErrorWhenNoneFound(t *testing.T) {
+	r := &fakeRunner{errs: map[string]error{
+		"podman version --format {{.Client.Version}}":  errors.New("not found"),
+		"docker version --format {{.Client.Version}}":   errors.New("not found"),
+		"nerdctl version --format {{.Client.Version}}": errors.New("not found"),
+	}}
+	if _, err := detect(r); err == nil {
+		t.Fatal("expected an error when no runtime is available")
+	}
+}
+
+func TestDockerImageExists(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"docker images -q qdrant/qdrant": "sha256:abc123\n",
+	}}
+	rt := newDocker(r)
+	ok, err := rt.ImageExists("qdrant/qdrant")
+	if err != nil || !ok {
+		t.Errorf("ImageExists() = (%v, %v), want (true, nil)", ok, err)
+	}
+}
+
+func TestPodmanImageExistsFallsBackToDockerIOPrefix(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"podman images -q qdrant/qdrant":            "",
+		"podman images -q docker.io/qdrant/qdrant": "abc123\n",
+	}}
+	rt := newPodman(r)
+	ok, err := rt.ImageExists("qdrant/qdrant")
+	if err != nil || !ok {
+		t.Errorf("ImageExists() = (%v, %v), want (true, nil)", ok, err)
+	}
+	if r.lastCall() != "podman images -q docker.io/qdrant/qdrant" {
+		t.Errorf("last call = %q, want the docker.io/-prefixed lookup", r.lastCall())
+	}
+}
+
+func TestDockerRunBuildsExpectedArgs(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"docker run -d --name qdrant -p 6333:6333 -v ./data:/qdrant/storage qdrant/qdrant:latest": "containerid123",
+	}}
+	rt := newDocker(r)
+	id, err := rt.Run(RunSpec{
+		Name:    "qdrant",
+		Image:   "qdrant/qdrant:latest",
+		Ports:   []string{"6333:6333"},
+		Volumes: []string{"./data:/qdrant/storage"},
+		Detach:  true,
+	})
+	if err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if id != "containerid123" {
+		t.Errorf("id = %q, want containerid123", id)
+	}
+}
+
+func TestDockerRunReportsNameConflict(t *testing.T) {
+	r := &fakeRunner{errs: map[string]error{
+		"docker run -d --name qdrant qdrant/qdrant:latest": errors.New(
+			"docker: Error response from daemon: Conflict. The container name \"/qdrant\" is already in use."),
+	}}
+	rt := newDocker(r)
+	_, err := rt.Run(RunSpec{Name: "qdrant", Image: "qdrant/qdrant:latest", Detach: true})
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if !strings.Contains(err.Error(), "already in use") {
+		t.Errorf("err = %v, want it to mention the name conflict", err)
+	}
+}
+
+func TestDockerInspectParsesFields(t *testing.T) {
+	r := &fakeRunner{outputs: map[string]string{
+		"docker inspect --format {{.Id}}|{{.Name}}|{{.Config.Image}}|{{.State.Running}} qdrant": "abc123|/qdrant|qdrant/qdrant:latest|true\n",
+	}}
+	rt := newDocker(r)
+	info, err := rt.Inspect("qdrant")
+	if err != nil {
+		t.Fatalf("Inspect: %v", err)
+	}
+	if info.Name != "qdrant" || !info.Running {
+		t.Errorf("info = %+v, want Name=qdrant Running=true", info)
+	}
+}
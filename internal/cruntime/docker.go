@@ -0,0 +1,87 @@
+package cruntime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// dockerRuntime drives the Docker CLI. The Engine API is reachable over
+// the same unix socket `docker` itself talks to, but shelling out keeps
+// this consistent with how the rest of the installer invokes external
+// tools (see internal/distro).
+type dockerRuntime struct {
+	run Runner
+}
+
+func newDocker(r Runner) Runtime { return &dockerRuntime{run: r} }
+
+func (d *dockerRuntime) Name() string { return "docker" }
+
+func (d *dockerRuntime) Version() (string, error) {
+	out, err := d.run.Output("docker", "version", "--format", "{{.Client.Version}}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (d *dockerRuntime) ImageExists(ref string) (bool, error) {
+	out, err := d.run.Output("docker", "images", "-q", ref)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+func (d *dockerRuntime) Pull(ref string) error {
+	_, err := d.run.Output("docker", "pull", ref)
+	return err
+}
+
+func (d *dockerRuntime) Run(spec RunSpec) (string, error) {
+	args := []string{"run"}
+	if spec.Detach {
+		args = append(args, "-d")
+	}
+	args = append(args, "--name", spec.Name)
+	for _, p := range spec.Ports {
+		args = append(args, "-p", p)
+	}
+	for _, v := range spec.Volumes {
+		args = append(args, "-v", v)
+	}
+	args = append(args, spec.Image)
+
+	out, err := d.run.Output("docker", args...)
+	if err != nil {
+		if strings.Contains(out, "already in use") {
+			return "", fmt.Errorf("container %q already in use: %w", spec.Name, err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (d *dockerRuntime) Stop(ref string) error {
+	_, err := d.run.Output("docker", "stop", ref)
+	return err
+}
+
+func (d *dockerRuntime) Logs(ref string, follow bool) (io.ReadCloser, error) {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, ref)
+	return d.run.Piped("docker", args...)
+}
+
+func (d *dockerRuntime) Inspect(ref string) (ContainerInfo, error) {
+	out, err := d.run.Output("docker", "inspect",
+		"--format", "{{.Id}}|{{.Name}}|{{.Config.Image}}|{{.State.Running}}", ref)
+	if err != nil {
+		return ContainerInfo{}, err
+	}
+	return parseInspect(out)
+}
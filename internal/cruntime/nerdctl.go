@@ -0,0 +1,86 @@
+package cruntime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// nerdctlRuntime drives nerdctl, the Docker-CLI-compatible frontend for
+// containerd. Its flags and inspect template fields line up with
+// docker's closely enough that this mirrors dockerRuntime almost exactly.
+type nerdctlRuntime struct {
+	run Runner
+}
+
+func newNerdctl(r Runner) Runtime { return &nerdctlRuntime{run: r} }
+
+func (n *nerdctlRuntime) Name() string { return "nerdctl" }
+
+func (n *nerdctlRuntime) Version() (string, error) {
+	out, err := n.run.Output("nerdctl", "version", "--format", "{{.Client.Version}}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (n *nerdctlRuntime) ImageExists(ref string) (bool, error) {
+	out, err := n.run.Output("nerdctl", "images", "-q", ref)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+func (n *nerdctlRuntime) Pull(ref string) error {
+	_, err := n.run.Output("nerdctl", "pull", ref)
+	return err
+}
+
+func (n *nerdctlRuntime) Run(spec RunSpec) (string, error) {
+	args := []string{"run"}
+	if spec.Detach {
+		args = append(args, "-d")
+	}
+	args = append(args, "--name", spec.Name)
+	for _, p := range spec.Ports {
+		args = append(args, "-p", p)
+	}
+	for _, v := range spec.Volumes {
+		args = append(args, "-v", v)
+	}
+	args = append(args, spec.Image)
+
+	out, err := n.run.Output("nerdctl", args...)
+	if err != nil {
+		if strings.Contains(out, "already in use") {
+			return "", fmt.Errorf("container %q already in use: %w", spec.Name, err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (n *nerdctlRuntime) Stop(ref string) error {
+	_, err := n.run.Output("nerdctl", "stop", ref)
+	return err
+}
+
+func (n *nerdctlRuntime) Logs(ref string, follow bool) (io.ReadCloser, error) {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, ref)
+	return n.run.Piped("nerdctl", args...)
+}
+
+func (n *nerdctlRuntime) Inspect(ref string) (ContainerInfo, error) {
+	out, err := n.run.Output("nerdctl", "inspect",
+		"--format", "{{.Id}}|{{.Name}}|{{.Config.Image}}|{{.State.Running}}", ref)
+	if err != nil {
+		return ContainerInfo{}, err
+	}
+	return parseInspect(out)
+}
@@ -0,0 +1,95 @@
+package cruntime
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// podmanRuntime drives the Podman CLI. Podman also exposes a Docker-
+// compatible REST API via `podman system service`, but the CLI is always
+// present wherever podman is, so it's what every call here uses.
+type podmanRuntime struct {
+	run Runner
+}
+
+func newPodman(r Runner) Runtime { return &podmanRuntime{run: r} }
+
+func (p *podmanRuntime) Name() string { return "podman" }
+
+func (p *podmanRuntime) Version() (string, error) {
+	out, err := p.run.Output("podman", "version", "--format", "{{.Client.Version}}")
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+// ImageExists also checks the docker.io/-prefixed form, since podman often
+// stores Docker Hub images under that fully-qualified name.
+func (p *podmanRuntime) ImageExists(ref string) (bool, error) {
+	if ok, err := p.imageExists(ref); ok || err != nil {
+		return ok, err
+	}
+	return p.imageExists("docker.io/" + ref)
+}
+
+func (p *podmanRuntime) imageExists(ref string) (bool, error) {
+	out, err := p.run.Output("podman", "images", "-q", ref)
+	if err != nil {
+		return false, err
+	}
+	return strings.TrimSpace(out) != "", nil
+}
+
+func (p *podmanRuntime) Pull(ref string) error {
+	_, err := p.run.Output("podman", "pull", ref)
+	return err
+}
+
+func (p *podmanRuntime) Run(spec RunSpec) (string, error) {
+	args := []string{"run"}
+	if spec.Detach {
+		args = append(args, "-d")
+	}
+	args = append(args, "--name", spec.Name)
+	for _, port := range spec.Ports {
+		args = append(args, "-p", port)
+	}
+	for _, v := range spec.Volumes {
+		args = append(args, "-v", v)
+	}
+	args = append(args, spec.Image)
+
+	out, err := p.run.Output("podman", args...)
+	if err != nil {
+		if strings.Contains(out, "already in use") {
+			return "", fmt.Errorf("container %q already in use: %w", spec.Name, err)
+		}
+		return "", err
+	}
+	return strings.TrimSpace(out), nil
+}
+
+func (p *podmanRuntime) Stop(ref string) error {
+	_, err := p.run.Output("podman", "stop", ref)
+	return err
+}
+
+func (p *podmanRuntime) Logs(ref string, follow bool) (io.ReadCloser, error) {
+	args := []string{"logs"}
+	if follow {
+		args = append(args, "-f")
+	}
+	args = append(args, ref)
+	return p.run.Piped("podman", args...)
+}
+
+func (p *podmanRuntime) Inspect(ref string) (ContainerInfo, error) {
+	out, err := p.run.Output("podman", "inspect",
+		"--format", "{{.Id}}|{{.Name}}|{{.Config.Image}}|{{.State.Running}}", ref)
+	if err != nil {
+		return ContainerInfo{}, err
+	}
+	return parseInspect(out)
+}
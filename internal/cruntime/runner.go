@@ -0,0 +1,27 @@
+package cruntime
+
+import (
+	"io"
+	"os/exec"
+
+	"github.com/strawberry-code/ragify/internal/procutil"
+)
+
+// Runner abstracts process execution so Runtime implementations can be
+// unit tested without a real container daemon; tests substitute a
+// fakeRunner that records invocations instead of an execRunner.
+type Runner interface {
+	Output(name string, args ...string) (string, error)
+	Piped(name string, args ...string) (io.ReadCloser, error)
+}
+
+type execRunner struct{}
+
+func (execRunner) Output(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	return string(out), err
+}
+
+func (execRunner) Piped(name string, args ...string) (io.ReadCloser, error) {
+	return procutil.StartPiped(name, args...)
+}
@@ -0,0 +1,24 @@
+package distro
+
+import "os/exec"
+
+// macOS installs via Homebrew and manages services with `brew services`.
+// It doesn't attempt to distinguish Intel vs Apple Silicon (brew itself
+// handles that), but keeps the door open for `sw_vers`/`uname` based
+// checks if that ever matters.
+type macOS struct{}
+
+func (m *macOS) Name() string { return "macos" }
+
+func (m *macOS) InstallPackages(packages []string) error {
+	args := append([]string{"install"}, packages...)
+	return exec.Command("brew", args...).Run()
+}
+
+func (m *macOS) EnableService(name string) error {
+	return exec.Command("brew", "services", "start", name).Run()
+}
+
+func (m *macOS) StartService(name string) error {
+	return exec.Command("brew", "services", "start", name).Run()
+}
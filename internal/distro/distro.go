@@ -0,0 +1,96 @@
+// Package distro detects the host operating system/distribution and
+// dispatches package-manager operations (install, service enable/start)
+// through the right backend instead of shelling out to a single
+// hardcoded installer script.
+package distro
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Distro abstracts the package manager and service manager of a host so
+// callers can install packages and manage services without caring whether
+// the machine runs apt, dnf, pacman, brew, or apk.
+type Distro interface {
+	// Name returns a human-readable identifier, e.g. "ubuntu" or "macos".
+	Name() string
+	// InstallPackages installs the given package names, translating
+	// generic names (e.g. "docker") to the distro's package names where
+	// needed.
+	InstallPackages(packages []string) error
+	// EnableService enables a service to start on boot.
+	EnableService(name string) error
+	// StartService starts a service immediately.
+	StartService(name string) error
+}
+
+var cached Distro
+
+// Detect identifies the current host's distro, caching the result for
+// subsequent calls the same way cruntime.Detect caches its pick.
+func Detect() (Distro, error) {
+	if cached != nil {
+		return cached, nil
+	}
+
+	var d Distro
+	var err error
+
+	switch runtime.GOOS {
+	case "darwin":
+		d = &macOS{}
+	case "linux":
+		d, err = detectLinux()
+	default:
+		return nil, fmt.Errorf("unsupported operating system: %s", runtime.GOOS)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	cached = d
+	return cached, nil
+}
+
+// detectLinux parses /etc/os-release to pick the right package manager.
+func detectLinux() (Distro, error) {
+	data, err := os.ReadFile("/etc/os-release")
+	if err != nil {
+		return nil, fmt.Errorf("reading /etc/os-release: %w", err)
+	}
+
+	fields := map[string]string{}
+	for _, line := range strings.Split(string(data), "\n") {
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		fields[key] = strings.Trim(value, `"`)
+	}
+
+	id := fields["ID"]
+	idLike := fields["ID_LIKE"]
+
+	switch {
+	case id == "ubuntu" || id == "debian" || strings.Contains(idLike, "debian"):
+		return &debian{}, nil
+	case id == "fedora" || id == "rhel" || id == "centos" || strings.Contains(idLike, "fedora") || strings.Contains(idLike, "rhel"):
+		return &fedora{}, nil
+	case id == "arch" || strings.Contains(idLike, "arch"):
+		return &arch{}, nil
+	case id == "alpine":
+		return &alpine{}, nil
+	default:
+		return nil, fmt.Errorf("unrecognized Linux distribution (ID=%q ID_LIKE=%q)", id, idLike)
+	}
+}
+
+// runSystemctl is shared by the Linux backends; Alpine uses OpenRC instead.
+func runSystemctl(args ...string) error {
+	cmd := exec.Command("sudo", append([]string{"systemctl"}, args...)...)
+	return cmd.Run()
+}
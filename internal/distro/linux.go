@@ -0,0 +1,64 @@
+package distro
+
+import "os/exec"
+
+// debian covers Ubuntu/Debian and apt-based derivatives.
+type debian struct{}
+
+func (d *debian) Name() string { return "debian" }
+
+func (d *debian) InstallPackages(packages []string) error {
+	update := exec.Command("sudo", "apt-get", "update")
+	if err := update.Run(); err != nil {
+		return err
+	}
+	args := append([]string{"apt-get", "install", "-y"}, packages...)
+	return exec.Command("sudo", args...).Run()
+}
+
+func (d *debian) EnableService(name string) error { return runSystemctl("enable", name) }
+func (d *debian) StartService(name string) error   { return runSystemctl("start", name) }
+
+// fedora covers Fedora/RHEL/CentOS and other dnf-based derivatives.
+type fedora struct{}
+
+func (f *fedora) Name() string { return "fedora" }
+
+func (f *fedora) InstallPackages(packages []string) error {
+	args := append([]string{"dnf", "install", "-y"}, packages...)
+	return exec.Command("sudo", args...).Run()
+}
+
+func (f *fedora) EnableService(name string) error { return runSystemctl("enable", name) }
+func (f *fedora) StartService(name string) error  { return runSystemctl("start", name) }
+
+// arch covers Arch Linux and pacman-based derivatives.
+type arch struct{}
+
+func (a *arch) Name() string { return "arch" }
+
+func (a *arch) InstallPackages(packages []string) error {
+	args := append([]string{"pacman", "-S", "--noconfirm"}, packages...)
+	return exec.Command("sudo", args...).Run()
+}
+
+func (a *arch) EnableService(name string) error { return runSystemctl("enable", name) }
+func (a *arch) StartService(name string) error  { return runSystemctl("start", name) }
+
+// alpine covers Alpine Linux, which uses apk and OpenRC rather than systemd.
+type alpine struct{}
+
+func (a *alpine) Name() string { return "alpine" }
+
+func (a *alpine) InstallPackages(packages []string) error {
+	args := append([]string{"apk", "add"}, packages...)
+	return exec.Command("sudo", args...).Run()
+}
+
+func (a *alpine) EnableService(name string) error {
+	return exec.Command("sudo", "rc-update", "add", name, "default").Run()
+}
+
+func (a *alpine) StartService(name string) error {
+	return exec.Command("sudo", "rc-service", name, "start").Run()
+}
@@ -0,0 +1,149 @@
+package installer
+
+import (
+	"fmt"
+	"os/exec"
+
+	"github.com/strawberry-code/ragify/internal/cruntime"
+	"github.com/strawberry-code/ragify/internal/distro"
+)
+
+// dockerPackages and ollamaPackages map a distro name (as reported by
+// distro.Distro.Name()) to the package(s) that provide it, so installation
+// doesn't depend on curl-piping an install script.
+var dockerPackages = map[string][]string{
+	"debian": {"docker.io"},
+	"fedora": {"docker"},
+	"arch":   {"docker"},
+	"alpine": {"docker"},
+	"macos":  {"--cask", "docker"},
+}
+
+var ollamaPackages = map[string][]string{
+	"debian": {"ollama"},
+	"fedora": {"ollama"},
+	"arch":   {"ollama"},
+	"alpine": {"ollama"},
+	"macos":  {"ollama"},
+}
+
+// InstallDocker installs a container runtime via the host's package
+// manager.
+func InstallDocker() (string, error) {
+	d, err := distro.Detect()
+	if err != nil {
+		return "Unsupported platform", err
+	}
+
+	pkgs, ok := dockerPackages[d.Name()]
+	if !ok {
+		return "Unsupported platform", fmt.Errorf("no docker package known for %s", d.Name())
+	}
+	if err := d.InstallPackages(pkgs); err != nil {
+		return "Failed", err
+	}
+
+	if d.Name() != "macos" {
+		if err := d.EnableService("docker"); err != nil {
+			return "Installed but failed to enable service", err
+		}
+		if err := d.StartService("docker"); err != nil {
+			return "Installed but failed to start service", err
+		}
+	}
+
+	return fmt.Sprintf("Installed via %s", d.Name()), nil
+}
+
+// InstallOllama installs Ollama and pulls model, the embedding model the
+// RAG pipeline will use (normally cfg.Embedding.Model).
+func InstallOllama(model string) (string, error) {
+	d, err := distro.Detect()
+	if err != nil {
+		return "Unsupported platform", err
+	}
+
+	pkgs, ok := ollamaPackages[d.Name()]
+	if !ok {
+		return "Unsupported platform", fmt.Errorf("no ollama package known for %s", d.Name())
+	}
+	if err := d.InstallPackages(pkgs); err != nil {
+		return "Failed", err
+	}
+
+	if d.Name() != "macos" {
+		if err := d.EnableService("ollama"); err != nil {
+			return "Installed but failed to enable service", err
+		}
+		if err := d.StartService("ollama"); err != nil {
+			return "Installed but failed to start service", err
+		}
+	}
+
+	if err := exec.Command("ollama", "pull", model).Run(); err != nil {
+		return "Installed but model pull failed", err
+	}
+	return fmt.Sprintf("Installed via %s with %s", d.Name(), model), nil
+}
+
+// InstallQdrant starts the qdrant vector database container.
+func InstallQdrant() (string, error) {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return "No container runtime found", err
+	}
+
+	if _, err := rt.Run(cruntime.RunSpec{
+		Name:    "qdrant",
+		Image:   "qdrant/qdrant:latest",
+		Ports:   []string{"6333:6333", "6334:6334"},
+		Volumes: []string{"./qdrant_storage:/qdrant/storage"},
+		Detach:  true,
+	}); err != nil {
+		if info, inspectErr := rt.Inspect("qdrant"); inspectErr == nil && info.Running {
+			return "Already running", nil
+		}
+		return "Failed", err
+	}
+
+	return fmt.Sprintf("Started in %s", rt.Name()), nil
+}
+
+// InstallMCPServer installs the MCP query server globally via npm.
+func InstallMCPServer() (string, error) {
+	output, err := exec.Command("npm", "install", "-g", "@qpd-v/mcp-server-ragdocs").CombinedOutput()
+	if err != nil {
+		return "Failed: " + string(output), err
+	}
+	return "Installed globally via npm", nil
+}
+
+// InstallPythonDeps installs the pip packages the RAG pipeline scripts
+// need.
+func InstallPythonDeps() (string, error) {
+	output, err := exec.Command("pip3", "install", "-r", "../requirements.txt").CombinedOutput()
+	if err != nil {
+		return "Failed: " + string(output), err
+	}
+	return "Installed requests, beautifulsoup4, chonkie, semchunk, tiktoken", nil
+}
+
+// RemoveQdrantContainer stops and removes the qdrant container; used by
+// uninstall and to roll back a partially-completed install, so a later
+// InstallQdrant can create the container again under the same name.
+func RemoveQdrantContainer() error {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return err
+	}
+	if err := rt.Stop("qdrant"); err != nil {
+		return err
+	}
+	return exec.Command(rt.Name(), "rm", "qdrant").Run()
+}
+
+// UninstallMCPServer removes the globally installed MCP server package;
+// used to roll back a partially-completed install.
+func UninstallMCPServer() error {
+	return exec.Command("npm", "uninstall", "-g", "@qpd-v/mcp-server-ragdocs").Run()
+}
@@ -0,0 +1,79 @@
+package installer
+
+import "github.com/strawberry-code/ragify/internal/config"
+
+// genericStep adapts a pair of check/apply functions to the Step
+// interface so callers don't need a bespoke type per component.
+type genericStep struct {
+	name       string
+	deps       []string
+	checkFn    func() bool
+	applyFn    func() (string, error)
+	rollbackFn func() error
+}
+
+func (s *genericStep) Name() string           { return s.name }
+func (s *genericStep) Dependencies() []string { return s.deps }
+func (s *genericStep) Check() bool            { return s.checkFn() }
+
+func (s *genericStep) Apply() error {
+	_, err := s.applyFn()
+	return err
+}
+
+func (s *genericStep) Rollback() error {
+	if s.rollbackFn == nil {
+		return nil
+	}
+	return s.rollbackFn()
+}
+
+// BuildPlan returns a Plan covering every installable component. selected
+// is which components the caller wants installed; installed is the
+// doctor-style snapshot of what's already present; cfg supplies the
+// settings individual steps need (currently just which embedding model
+// InstallOllama pulls). Components that are either unselected or already
+// installed are modeled as already-satisfied steps rather than omitted,
+// so dependency ordering (qdrant after docker) still holds even when only
+// a subset was chosen.
+func BuildPlan(selected, installed map[string]bool, cfg *config.Config) (*Plan, error) {
+	satisfied := func(component string) bool {
+		return !selected[component] || installed[component]
+	}
+
+	steps := []Step{
+		&genericStep{
+			name:    "docker",
+			checkFn: func() bool { return satisfied("docker") || CheckDocker() },
+			applyFn: InstallDocker,
+			// Docker is a system-wide package; we don't uninstall it on rollback.
+		},
+		&genericStep{
+			name:    "ollama",
+			checkFn: func() bool { return satisfied("ollama") || CheckOllama() },
+			applyFn: func() (string, error) { return InstallOllama(cfg.Embedding.Model) },
+		},
+		&genericStep{
+			name:       "qdrant",
+			deps:       []string{"docker"},
+			checkFn:    func() bool { return satisfied("qdrant") || CheckQdrantInstalled() },
+			applyFn:    InstallQdrant,
+			rollbackFn: RemoveQdrantContainer,
+		},
+		&genericStep{
+			name:       "mcp_server",
+			checkFn:    func() bool { return satisfied("mcp_server") || CheckMCPServerInstalled() },
+			applyFn:    InstallMCPServer,
+			rollbackFn: UninstallMCPServer,
+		},
+		&genericStep{
+			name:    "python_deps",
+			checkFn: func() bool { return satisfied("python_deps") || CheckPythonDeps() },
+			applyFn: InstallPythonDeps,
+			// pip packages are left in place on rollback; nothing else on
+			// the system depends on them being absent.
+		},
+	}
+
+	return NewPlan(steps)
+}
@@ -0,0 +1,180 @@
+package installer
+
+import (
+	"fmt"
+	"net/http"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/strawberry-code/ragify/internal/cruntime"
+)
+
+// GetContainerRuntime returns the name of the detected container runtime
+// (podman, docker, or nerdctl, in that preference order), or "" if none is
+// on PATH. Most callers that just need the CLI binary name (e.g. the
+// services package) use this; callers that need to actually drive the
+// runtime use cruntime.Detect directly.
+func GetContainerRuntime() string {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return ""
+	}
+	return rt.Name()
+}
+
+// RuntimeInfo returns a human-readable "name version" string describing
+// the detected container runtime, for display on the doctor screen.
+func RuntimeInfo() string {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return "none detected"
+	}
+	version, err := rt.Version()
+	if err != nil {
+		return rt.Name()
+	}
+	return fmt.Sprintf("%s %s", rt.Name(), version)
+}
+
+// CheckDocker reports whether a container runtime is available.
+func CheckDocker() bool {
+	return GetContainerRuntime() != ""
+}
+
+// CheckDockerRunning reports whether the container runtime's daemon is
+// reachable.
+func CheckDockerRunning() bool {
+	runtime := GetContainerRuntime()
+	if runtime == "" {
+		return false
+	}
+	return exec.Command(runtime, "ps").Run() == nil
+}
+
+// CheckOllama reports whether Ollama is installed and serving.
+func CheckOllama() bool {
+	return CheckOllamaRunning()
+}
+
+// CheckOllamaRunning reports whether Ollama's API is reachable.
+func CheckOllamaRunning() bool {
+	resp, err := http.Get("http://localhost:11434/api/tags")
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == 200
+}
+
+// CheckQdrantInstalled reports whether the qdrant/qdrant image has been
+// pulled.
+func CheckQdrantInstalled() bool {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return false
+	}
+	ok, err := rt.ImageExists("qdrant/qdrant")
+	return err == nil && ok
+}
+
+// CheckQdrantRunning reports whether the qdrant container is up.
+func CheckQdrantRunning() bool {
+	rt, err := cruntime.Detect()
+	if err != nil {
+		return false
+	}
+	info, err := rt.Inspect("qdrant")
+	return err == nil && info.Running
+}
+
+// CheckMCPServerInstalled reports whether the mcp-server-ragdocs npm
+// package is installed globally.
+func CheckMCPServerInstalled() bool {
+	out, err := exec.Command("npm", "list", "-g", "--depth=0").Output()
+	if err != nil {
+		return false
+	}
+	return strings.Contains(string(out), "@qpd-v/mcp-server-ragdocs")
+}
+
+// CheckMCPServerRunning reports whether the mcp-server-ragdocs process is
+// running.
+func CheckMCPServerRunning() bool {
+	return exec.Command("pgrep", "-f", "mcp-server-ragdocs").Run() == nil
+}
+
+// CheckNodeJS reports whether node is on PATH.
+func CheckNodeJS() bool {
+	out, err := exec.Command("node", "--version").Output()
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(strings.TrimSpace(string(out)), "v")
+}
+
+// CheckPython reports whether Python 3.10+ is on PATH (required by
+// Chonkie).
+func CheckPython() bool {
+	out, err := exec.Command("python3", "--version").Output()
+	if err != nil {
+		return false
+	}
+	version := strings.TrimSpace(string(out))
+
+	if !strings.Contains(version, "Python 3.") {
+		return false
+	}
+
+	parts := strings.Fields(version)
+	if len(parts) < 2 {
+		return false
+	}
+	versionParts := strings.Split(parts[1], ".")
+	if len(versionParts) < 2 {
+		return false
+	}
+	if versionParts[0] != "3" {
+		return false
+	}
+
+	var minorVersion int
+	fmt.Sscanf(versionParts[1], "%d", &minorVersion)
+	return minorVersion >= 10
+}
+
+// CheckPythonDeps reports whether the RAG pipeline's pip packages are
+// installed.
+func CheckPythonDeps() bool {
+	out, err := exec.Command("pip3", "list").Output()
+	if err != nil {
+		return false
+	}
+	output := string(out)
+
+	requiredPackages := []string{
+		"requests",
+		"beautifulsoup4",
+		"chonkie",
+		"semchunk",
+		"tiktoken",
+	}
+
+	for _, pkg := range requiredPackages {
+		if !strings.Contains(output, pkg) {
+			return false
+		}
+	}
+	return true
+}
+
+// CheckDiskSpace reports whether at least 5GB is free on /.
+func CheckDiskSpace() bool {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs("/", &stat); err != nil {
+		return false
+	}
+	available := stat.Bavail * uint64(stat.Bsize)
+	const fiveGB = 5 * 1024 * 1024 * 1024
+	return available > fiveGB
+}
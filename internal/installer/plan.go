@@ -0,0 +1,202 @@
+// Package installer turns the fixed install-these-five-things sequence
+// that used to live in main.go into a dependency-ordered, resumable Plan:
+// steps declare what they depend on, the Plan topologically sorts them,
+// skips anything already satisfied (via Check or a prior run's persisted
+// state), and rolls back what it applied if asked to abort.
+package installer
+
+import "fmt"
+
+// Step is one unit of installation work (e.g. "docker", "qdrant").
+type Step interface {
+	// Name uniquely identifies the step; other steps reference it in
+	// Dependencies.
+	Name() string
+	// Dependencies lists the names of steps that must complete first.
+	Dependencies() []string
+	// Check reports whether the step is already satisfied on this
+	// machine, so Apply can be skipped.
+	Check() bool
+	// Apply performs the installation.
+	Apply() error
+	// Rollback undoes Apply. Only called on steps this Plan actually
+	// applied.
+	Rollback() error
+}
+
+// StepResult reports the outcome of running one step.
+type StepResult struct {
+	Name   string
+	Status string
+	Err    error
+}
+
+// Plan is a topologically sorted, resumable sequence of Steps.
+type Plan struct {
+	order     []Step
+	byName    map[string]Step
+	applied   []Step // steps this Plan instance actually Applied, for Rollback
+	state     *State
+	statePath string
+}
+
+// NewPlan sorts steps by their declared dependencies and loads progress
+// from the default state file (~/.config/ragify/state.json).
+func NewPlan(steps []Step) (*Plan, error) {
+	path, err := DefaultStatePath()
+	if err != nil {
+		return nil, err
+	}
+	return NewPlanWithStatePath(steps, path)
+}
+
+// NewPlanWithStatePath is NewPlan with an explicit state file location,
+// mainly so tests don't touch the user's real config directory.
+func NewPlanWithStatePath(steps []Step, statePath string) (*Plan, error) {
+	order, err := topoSort(steps)
+	if err != nil {
+		return nil, err
+	}
+
+	state, err := loadState(statePath)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := make(map[string]Step, len(steps))
+	for _, s := range steps {
+		byName[s.Name()] = s
+	}
+
+	return &Plan{order: order, byName: byName, state: state, statePath: statePath}, nil
+}
+
+// Pending returns the steps not yet marked complete, in dependency order.
+func (p *Plan) Pending() []Step {
+	var pending []Step
+	for _, s := range p.order {
+		if !p.state.Completed[s.Name()] {
+			pending = append(pending, s)
+		}
+	}
+	return pending
+}
+
+// RunNext runs the next pending step (skipping it via Check if already
+// satisfied) and reports what happened. It returns ok=false once every
+// step has completed. A failed Apply is reported but does not advance or
+// roll back anything automatically — the caller decides whether to Retry
+// the failed step or Rollback the whole run.
+func (p *Plan) RunNext() (StepResult, bool) {
+	pending := p.Pending()
+	if len(pending) == 0 {
+		return StepResult{}, false
+	}
+
+	step := pending[0]
+	if step.Check() {
+		p.markComplete(step)
+		return StepResult{Name: step.Name(), Status: "already satisfied"}, true
+	}
+
+	if err := step.Apply(); err != nil {
+		return StepResult{Name: step.Name(), Status: "failed", Err: err}, true
+	}
+
+	p.applied = append(p.applied, step)
+	p.markComplete(step)
+	return StepResult{Name: step.Name(), Status: "installed"}, true
+}
+
+// Retry re-attempts Apply for a named step that previously failed.
+func (p *Plan) Retry(name string) (StepResult, error) {
+	step, ok := p.byName[name]
+	if !ok {
+		return StepResult{}, fmt.Errorf("installer: no such step %q", name)
+	}
+
+	if err := step.Apply(); err != nil {
+		return StepResult{Name: name, Status: "failed", Err: err}, nil
+	}
+
+	p.applied = append(p.applied, step)
+	p.markComplete(step)
+	return StepResult{Name: name, Status: "installed"}, nil
+}
+
+// Rollback undoes every step this Plan applied during its lifetime, most
+// recently applied first, and clears them from the persisted state.
+func (p *Plan) Rollback() error {
+	var firstErr error
+	for i := len(p.applied) - 1; i >= 0; i-- {
+		step := p.applied[i]
+		if err := step.Rollback(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(p.state.Completed, step.Name())
+	}
+	p.applied = nil
+	if err := p.state.save(p.statePath); err != nil && firstErr == nil {
+		firstErr = err
+	}
+	return firstErr
+}
+
+func (p *Plan) markComplete(step Step) {
+	p.state.Completed[step.Name()] = true
+	// Persisting is best-effort: a failure here shouldn't crash the
+	// install, just cost the user a resume point.
+	_ = p.state.save(p.statePath)
+}
+
+// topoSort orders steps so each appears after everything in its
+// Dependencies, using Kahn's algorithm, and errors on missing deps or
+// cycles.
+func topoSort(steps []Step) ([]Step, error) {
+	byName := make(map[string]Step, len(steps))
+	indegree := make(map[string]int, len(steps))
+	dependents := make(map[string][]string, len(steps))
+
+	for _, s := range steps {
+		byName[s.Name()] = s
+		if _, ok := indegree[s.Name()]; !ok {
+			indegree[s.Name()] = 0
+		}
+	}
+
+	for _, s := range steps {
+		for _, dep := range s.Dependencies() {
+			if _, ok := byName[dep]; !ok {
+				return nil, fmt.Errorf("installer: step %q depends on unknown step %q", s.Name(), dep)
+			}
+			indegree[s.Name()]++
+			dependents[dep] = append(dependents[dep], s.Name())
+		}
+	}
+
+	var ready []string
+	for _, s := range steps {
+		if indegree[s.Name()] == 0 {
+			ready = append(ready, s.Name())
+		}
+	}
+
+	var order []Step
+	for len(ready) > 0 {
+		name := ready[0]
+		ready = ready[1:]
+		order = append(order, byName[name])
+
+		for _, dependent := range dependents[name] {
+			indegree[dependent]--
+			if indegree[dependent] == 0 {
+				ready = append(ready, dependent)
+			}
+		}
+	}
+
+	if len(order) != len(steps) {
+		return nil, fmt.Errorf("installer: dependency cycle detected among steps")
+	}
+	return order, nil
+}
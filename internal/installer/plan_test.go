@@ -0,0 +1,180 @@
+package installer
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+)
+
+// fakeStep is an in-memory Step for exercising Plan without touching the
+// real system.
+type fakeStep struct {
+	name       string
+	deps       []string
+	satisfied  bool
+	applyErr   error
+	applied    bool
+	rolledBack bool
+}
+
+func (f *fakeStep) Name() string           { return f.name }
+func (f *fakeStep) Dependencies() []string { return f.deps }
+func (f *fakeStep) Check() bool            { return f.satisfied }
+
+func (f *fakeStep) Apply() error {
+	if f.applyErr != nil {
+		return f.applyErr
+	}
+	f.applied = true
+	return nil
+}
+
+func (f *fakeStep) Rollback() error {
+	f.rolledBack = true
+	f.applied = false
+	return nil
+}
+
+func newTestPlan(t *testing.T, steps []Step) *Plan {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "state.json")
+	p, err := NewPlanWithStatePath(steps, path)
+	if err != nil {
+		t.Fatalf("NewPlanWithStatePath: %v", err)
+	}
+	return p
+}
+
+func TestPlanOrdersByDependency(t *testing.T) {
+	docker := &fakeStep{name: "docker"}
+	qdrant := &fakeStep{name: "qdrant", deps: []string{"docker"}}
+	python := &fakeStep{name: "python"}
+	pythonDeps := &fakeStep{name: "python_deps", deps: []string{"python"}}
+
+	// Construct out of dependency order to prove the sort does the work.
+	p := newTestPlan(t, []Step{qdrant, pythonDeps, docker, python})
+
+	var order []string
+	for {
+		result, ok := p.RunNext()
+		if !ok {
+			break
+		}
+		order = append(order, result.Name)
+	}
+
+	pos := map[string]int{}
+	for i, name := range order {
+		pos[name] = i
+	}
+	if pos["docker"] > pos["qdrant"] {
+		t.Errorf("expected docker before qdrant, got order %v", order)
+	}
+	if pos["python"] > pos["python_deps"] {
+		t.Errorf("expected python before python_deps, got order %v", order)
+	}
+}
+
+func TestPlanSkipsAlreadySatisfiedSteps(t *testing.T) {
+	docker := &fakeStep{name: "docker", satisfied: true}
+	p := newTestPlan(t, []Step{docker})
+
+	result, ok := p.RunNext()
+	if !ok {
+		t.Fatalf("expected a result")
+	}
+	if result.Status != "already satisfied" {
+		t.Errorf("status = %q, want %q", result.Status, "already satisfied")
+	}
+	if docker.applied {
+		t.Errorf("Apply should not have been called on an already-satisfied step")
+	}
+}
+
+func TestPlanResumesFromPersistedState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	docker := &fakeStep{name: "docker"}
+	ollama := &fakeStep{name: "ollama"}
+	p1, err := NewPlanWithStatePath([]Step{docker, ollama}, path)
+	if err != nil {
+		t.Fatalf("NewPlanWithStatePath: %v", err)
+	}
+	if _, ok := p1.RunNext(); !ok { // completes docker
+		t.Fatalf("expected docker to run")
+	}
+
+	// A fresh Plan over the same state file should skip docker and start
+	// straight at ollama.
+	dockerAgain := &fakeStep{name: "docker"}
+	ollamaAgain := &fakeStep{name: "ollama"}
+	p2, err := NewPlanWithStatePath([]Step{dockerAgain, ollamaAgain}, path)
+	if err != nil {
+		t.Fatalf("NewPlanWithStatePath: %v", err)
+	}
+
+	pending := p2.Pending()
+	if len(pending) != 1 || pending[0].Name() != "ollama" {
+		t.Fatalf("expected only ollama pending after resume, got %v", pending)
+	}
+	if dockerAgain.applied {
+		t.Errorf("resumed plan should not re-apply the completed docker step")
+	}
+}
+
+func TestPlanRetryAfterFailure(t *testing.T) {
+	step := &fakeStep{name: "qdrant", applyErr: errors.New("boom")}
+	p := newTestPlan(t, []Step{step})
+
+	result, ok := p.RunNext()
+	if !ok || result.Status != "failed" {
+		t.Fatalf("expected first attempt to fail, got %+v", result)
+	}
+	if len(p.Pending()) != 1 {
+		t.Fatalf("failed step should still be pending")
+	}
+
+	step.applyErr = nil
+	retryResult, err := p.Retry("qdrant")
+	if err != nil {
+		t.Fatalf("Retry: %v", err)
+	}
+	if retryResult.Status != "installed" {
+		t.Errorf("status = %q, want %q", retryResult.Status, "installed")
+	}
+	if len(p.Pending()) != 0 {
+		t.Errorf("expected no pending steps after successful retry")
+	}
+}
+
+func TestPlanRollbackUndoesAppliedSteps(t *testing.T) {
+	docker := &fakeStep{name: "docker"}
+	qdrant := &fakeStep{name: "qdrant", deps: []string{"docker"}}
+	p := newTestPlan(t, []Step{docker, qdrant})
+
+	if _, ok := p.RunNext(); !ok { // docker
+		t.Fatalf("expected docker to run")
+	}
+	if _, ok := p.RunNext(); !ok { // qdrant
+		t.Fatalf("expected qdrant to run")
+	}
+
+	if err := p.Rollback(); err != nil {
+		t.Fatalf("Rollback: %v", err)
+	}
+	if !docker.rolledBack || !qdrant.rolledBack {
+		t.Errorf("expected both steps rolled back, got docker=%v qdrant=%v", docker.rolledBack, qdrant.rolledBack)
+	}
+	if len(p.Pending()) != 2 {
+		t.Errorf("expected both steps pending again after rollback, got %d", len(p.Pending()))
+	}
+}
+
+func TestTopoSortDetectsCycle(t *testing.T) {
+	a := &fakeStep{name: "a", deps: []string{"b"}}
+	b := &fakeStep{name: "b", deps: []string{"a"}}
+
+	if _, err := NewPlanWithStatePath([]Step{a, b}, filepath.Join(t.TempDir(), "state.json")); err == nil {
+		t.Fatal("expected cycle to be rejected")
+	}
+}
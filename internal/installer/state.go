@@ -0,0 +1,57 @@
+package installer
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// State is the on-disk record of which steps have already completed, so a
+// re-run of the installer can resume instead of redoing finished work.
+type State struct {
+	Completed map[string]bool `json:"completed"`
+}
+
+// DefaultStatePath returns ~/.config/ragify/state.json, creating the
+// containing directory if needed.
+func DefaultStatePath() (string, error) {
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	dir := filepath.Join(home, ".config", "ragify")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "state.json"), nil
+}
+
+// loadState reads State from path, returning a fresh empty State if the
+// file doesn't exist yet.
+func loadState(path string) (*State, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return &State{Completed: map[string]bool{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s State
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	if s.Completed == nil {
+		s.Completed = map[string]bool{}
+	}
+	return &s, nil
+}
+
+// save persists State to path as indented JSON.
+func (s *State) save(path string) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
@@ -0,0 +1,42 @@
+// Package procutil holds the process-piping helper shared by the
+// cruntime and services packages, which both need to follow a
+// long-running command's stdout (container logs, journalctl -f) and reap
+// the process once the caller stops reading.
+package procutil
+
+import (
+	"io"
+	"os"
+	"os/exec"
+)
+
+// StartPiped starts name with args, wiring its stderr to the current
+// process's so errors aren't swallowed, and returns its stdout as a
+// ReadCloser whose Close also kills and reaps the process — so a caller
+// abandoning a followed stream (e.g. `docker logs -f`) doesn't leak it.
+func StartPiped(name string, args ...string) (io.ReadCloser, error) {
+	cmd := exec.Command(name, args...)
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return nil, err
+	}
+	cmd.Stderr = os.Stderr
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+	return &cmdReadCloser{ReadCloser: stdout, cmd: cmd}, nil
+}
+
+// cmdReadCloser closes the underlying pipe and reaps the process when the
+// caller is done reading, so following a log stream doesn't leak it.
+type cmdReadCloser struct {
+	io.ReadCloser
+	cmd *exec.Cmd
+}
+
+func (c *cmdReadCloser) Close() error {
+	err := c.ReadCloser.Close()
+	_ = c.cmd.Process.Kill()
+	_ = c.cmd.Wait()
+	return err
+}
@@ -0,0 +1,86 @@
+package ragcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// ExportOptions configures a full-collection embeddings dump.
+type ExportOptions struct {
+	QdrantURL string // e.g. "http://localhost:6333", normally cfg.VectorStore.Location
+	BatchSize int    // points fetched per qdrant scroll request
+}
+
+// DefaultExportOptions returns the options used when none are given on
+// the command line.
+func DefaultExportOptions() ExportOptions {
+	return ExportOptions{QdrantURL: "http://localhost:6333", BatchSize: 100}
+}
+
+// ExportedPoint is one vector plus its payload and qdrant point ID, the
+// shape Export writes one per line as JSON.
+type ExportedPoint struct {
+	ID      interface{}            `json:"id"`
+	Vector  []float64              `json:"vector"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Export streams every point in the qdrant documentation collection to w
+// as newline-delimited JSON, paging through qdrant's scroll API so the
+// whole collection doesn't need to fit in memory at once.
+func Export(w io.Writer, opts ExportOptions) error {
+	enc := json.NewEncoder(w)
+
+	var offset interface{}
+	for {
+		points, next, err := scroll(opts.QdrantURL, opts.BatchSize, offset)
+		if err != nil {
+			return err
+		}
+		for _, p := range points {
+			if err := enc.Encode(p); err != nil {
+				return err
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		offset = next
+	}
+}
+
+func scroll(qdrantURL string, limit int, offset interface{}) ([]ExportedPoint, interface{}, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"limit":        limit,
+		"offset":       offset,
+		"with_payload": true,
+		"with_vector":  true,
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	url := fmt.Sprintf("%s/collections/%s/points/scroll", qdrantURL, collectionName)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, nil, fmt.Errorf("qdrant returned %s", resp.Status)
+	}
+
+	var out struct {
+		Result struct {
+			Points         []ExportedPoint `json:"points"`
+			NextPageOffset interface{}     `json:"next_page_offset"`
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, nil, err
+	}
+	return out.Result.Points, out.Result.NextPageOffset, nil
+}
@@ -0,0 +1,136 @@
+package ragcore
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"time"
+
+	"github.com/strawberry-code/ragify/internal/config"
+)
+
+// IngestOptions configures a single corpus indexing run.
+type IngestOptions struct {
+	Port      int    // port docs_server.py serves the corpus on while it's chunked
+	QdrantURL string // e.g. "http://localhost:6333", normally cfg.VectorStore.Location
+	ChunkSize int    // tokens per chunk add_urls_to_qdrant.py splits documents into
+}
+
+// DefaultIngestOptions returns the options used when none are given on
+// the command line.
+func DefaultIngestOptions() IngestOptions {
+	return IngestOptions{Port: 8000, QdrantURL: "http://localhost:6333", ChunkSize: 512}
+}
+
+// Ingest indexes path into qdrant by running the same three-script
+// pipeline the installer's completion screen has always told users to run
+// by hand: serve the corpus locally, generate a URL list over it, then
+// chunk and embed each URL into qdrant.
+func Ingest(path string, opts IngestOptions) error {
+	serve := exec.Command("python3", "docs_server.py", path, "--port", fmt.Sprint(opts.Port))
+	serve.Stderr = os.Stderr
+	if err := serve.Start(); err != nil {
+		return fmt.Errorf("starting docs_server.py: %w", err)
+	}
+	defer func() {
+		_ = serve.Process.Kill()
+		_ = serve.Wait()
+	}()
+	time.Sleep(500 * time.Millisecond) // give docs_server.py a moment to bind
+
+	urlGen := exec.Command("python3", "local_docs_url_generator.py", path, "-o", "urls.txt")
+	urlGen.Stdout = os.Stdout
+	urlGen.Stderr = os.Stderr
+	if err := urlGen.Run(); err != nil {
+		return fmt.Errorf("generating url list: %w", err)
+	}
+
+	load := exec.Command("python3", "add_urls_to_qdrant.py", "urls.txt",
+		"--qdrant-url", opts.QdrantURL, "--chunk-size", fmt.Sprint(opts.ChunkSize))
+	load.Stdout = os.Stdout
+	load.Stderr = os.Stderr
+	if err := load.Run(); err != nil {
+		return fmt.Errorf("loading urls into qdrant: %w", err)
+	}
+	return nil
+}
+
+// IngestStdinDir drains r into a single markdown file inside a fresh temp
+// directory, so piped input ("ragify ingest -") can feed the same
+// directory-based Ingest pipeline a real corpus path uses. The caller is
+// responsible for removing the returned directory once ingestion is done.
+func IngestStdinDir(r io.Reader) (string, error) {
+	dir, err := os.MkdirTemp("", "ragify-ingest-")
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(filepath.Join(dir, "stdin.md"))
+	if err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		os.RemoveAll(dir)
+		return "", err
+	}
+	return dir, nil
+}
+
+// IngestSourceDir copies every file matching src's glob into a fresh temp
+// directory, the same staging pattern IngestStdinDir uses for piped
+// input, so a named entry from cfg.Sources can feed the directory-based
+// Ingest pipeline just like a path on disk does. The caller is
+// responsible for removing the returned directory once ingestion is done.
+func IngestSourceDir(src config.Source) (string, error) {
+	matches, err := filepath.Glob(src.Glob)
+	if err != nil {
+		return "", fmt.Errorf("source %q: invalid glob %q: %w", src.Name, src.Glob, err)
+	}
+	if len(matches) == 0 {
+		return "", fmt.Errorf("source %q: glob %q matched no files", src.Name, src.Glob)
+	}
+
+	dir, err := os.MkdirTemp("", "ragify-ingest-")
+	if err != nil {
+		return "", err
+	}
+	for _, m := range matches {
+		if err := copyFile(m, filepath.Join(dir, filepath.Base(m))); err != nil {
+			os.RemoveAll(dir)
+			return "", err
+		}
+	}
+	return dir, nil
+}
+
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+	defer out.Close()
+
+	_, err = io.Copy(out, in)
+	return err
+}
+
+// Serve runs docs_server.py in the foreground, serving path until the
+// process exits or is killed. Ingest also uses docs_server.py, but only
+// transiently in the background; Serve is for standing it up on its own.
+func Serve(path string, port int) error {
+	cmd := exec.Command("python3", "docs_server.py", path, "--port", fmt.Sprint(port))
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = os.Stderr
+	return cmd.Run()
+}
@@ -0,0 +1,107 @@
+// Package ragcore is the service layer both the Bubble Tea wizard and the
+// ragify CLI call into for the actual RAG actions (ingest, query, serve,
+// export), so neither frontend duplicates how those actions talk to
+// Ollama and qdrant.
+package ragcore
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+const (
+	// ollamaURL has no config.Config knob yet (EmbeddingConfig only names
+	// a model, not a host), so it stays a constant until one exists.
+	ollamaURL = "http://localhost:11434"
+	// collectionName matches the collection the mcp-server-ragdocs tools
+	// (and the installer's add_urls_to_qdrant.py) write their embeddings
+	// into.
+	collectionName = "documentation"
+)
+
+// QueryOptions configures a single retrieval request.
+type QueryOptions struct {
+	Model     string // embedding model, e.g. "nomic-embed-text"
+	TopK      int
+	Format    string // "plain" or "json"
+	QdrantURL string // e.g. "http://localhost:6333", normally cfg.VectorStore.Location
+}
+
+// DefaultQueryOptions mirrors the embedding model InstallOllama pulls, the
+// qdrant location InstallQdrant exposes, and the result count the
+// installer's completion screen uses in its search_documentation example.
+func DefaultQueryOptions() QueryOptions {
+	return QueryOptions{Model: "nomic-embed-text", TopK: 5, Format: "plain", QdrantURL: "http://localhost:6333"}
+}
+
+// SearchResult is one match returned by Query.
+type SearchResult struct {
+	Score   float64                `json:"score"`
+	Payload map[string]interface{} `json:"payload"`
+}
+
+// Query embeds question via Ollama and searches the qdrant documentation
+// collection for its nearest neighbors — the same two steps the
+// mcp-server-ragdocs search_documentation tool performs, exposed here so
+// a one-shot lookup doesn't require an MCP client.
+func Query(question string, opts QueryOptions) ([]SearchResult, error) {
+	vector, err := embed(question, opts.Model)
+	if err != nil {
+		return nil, fmt.Errorf("embedding query: %w", err)
+	}
+	return search(vector, opts.TopK, opts.QdrantURL)
+}
+
+func embed(text, model string) ([]float64, error) {
+	body, err := json.Marshal(map[string]string{"model": model, "prompt": text})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := http.Post(ollamaURL+"/api/embeddings", "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("ollama returned %s", resp.Status)
+	}
+
+	var out struct {
+		Embedding []float64 `json:"embedding"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Embedding, nil
+}
+
+func search(vector []float64, topK int, qdrantURL string) ([]SearchResult, error) {
+	body, err := json.Marshal(map[string]interface{}{
+		"vector":       vector,
+		"limit":        topK,
+		"with_payload": true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	url := fmt.Sprintf("%s/collections/%s/points/search", qdrantURL, collectionName)
+	resp, err := http.Post(url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("qdrant returned %s", resp.Status)
+	}
+
+	var out struct {
+		Result []SearchResult `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&out); err != nil {
+		return nil, err
+	}
+	return out.Result, nil
+}
@@ -0,0 +1,149 @@
+// Package services describes the services the installer knows how to
+// manage (the qdrant container, the ollama systemd unit, the mcp-server
+// npm process) and how to pull logs/stats/env/top information out of
+// each one, regardless of how it's actually running.
+package services
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+
+	"github.com/strawberry-code/ragify/internal/procutil"
+)
+
+// Kind identifies how a Service is hosted, which determines which shell
+// commands back its Logs/Stats/Env/Top calls.
+type Kind int
+
+const (
+	// ContainerKind services run under the detected container runtime
+	// (docker, podman, or nerdctl).
+	ContainerKind Kind = iota
+	// SystemdKind services run as a systemd unit.
+	SystemdKind
+	// ProcessKind services are a bare OS process found by name.
+	ProcessKind
+)
+
+// Service is one entry in the doctor panel: qdrant, ollama, or mcp-server.
+type Service struct {
+	Name    string // display name, e.g. "qdrant"
+	Kind    Kind
+	Runtime string // container runtime ("docker"/"podman"/"nerdctl"), empty for non-container kinds
+}
+
+// Logs returns a stream of the service's log output. When follow is true
+// the returned ReadCloser stays open and keeps producing lines (like
+// `docker logs -f` or `journalctl -f`) until closed by the caller.
+func (s Service) Logs(follow bool) (io.ReadCloser, error) {
+	switch s.Kind {
+	case ContainerKind:
+		args := []string{"logs"}
+		if follow {
+			args = append(args, "-f")
+		}
+		args = append(args, s.Name)
+		return s.startPiped(s.Runtime, args...)
+	case SystemdKind:
+		args := []string{"-u", s.Name}
+		if follow {
+			args = append(args, "-f")
+		}
+		return s.startPiped("journalctl", args...)
+	default:
+		return nil, fmt.Errorf("%s: logs are not available for process services", s.Name)
+	}
+}
+
+// Stats returns a one-shot snapshot of resource usage.
+func (s Service) Stats() (string, error) {
+	switch s.Kind {
+	case ContainerKind:
+		return s.output(s.Runtime, "stats", "--no-stream", s.Name)
+	case SystemdKind:
+		return s.output("systemctl", "show", s.Name, "--property=MemoryCurrent,CPUUsageNSec")
+	default:
+		return s.output("ps", "-o", "pid,%cpu,%mem,etime", "-C", s.Name)
+	}
+}
+
+// Env returns the service's environment/config as displayed text.
+func (s Service) Env() (string, error) {
+	switch s.Kind {
+	case ContainerKind:
+		return s.output(s.Runtime, "inspect", "--format", "{{json .Config.Env}}", s.Name)
+	case SystemdKind:
+		return s.output("systemctl", "show", s.Name, "--property=Environment")
+	default:
+		pid, err := s.output("pgrep", "-f", s.Name)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("pid %s (read /proc/%s/environ for details)", pid, pid), nil
+	}
+}
+
+// Top lists the processes running inside/under the service.
+func (s Service) Top() (string, error) {
+	switch s.Kind {
+	case ContainerKind:
+		return s.output(s.Runtime, "top", s.Name)
+	case SystemdKind:
+		return s.output("systemctl", "status", s.Name, "--no-pager", "-l")
+	default:
+		return s.output("pgrep", "-af", s.Name)
+	}
+}
+
+// Restart restarts the service.
+func (s Service) Restart() error {
+	switch s.Kind {
+	case ContainerKind:
+		return exec.Command(s.Runtime, "restart", s.Name).Run()
+	case SystemdKind:
+		return exec.Command("sudo", "systemctl", "restart", s.Name).Run()
+	default:
+		return fmt.Errorf("%s: process services must be restarted manually", s.Name)
+	}
+}
+
+// Stop stops the service.
+func (s Service) Stop() error {
+	switch s.Kind {
+	case ContainerKind:
+		return exec.Command(s.Runtime, "stop", s.Name).Run()
+	case SystemdKind:
+		return exec.Command("sudo", "systemctl", "stop", s.Name).Run()
+	default:
+		return exec.Command("pkill", "-f", s.Name).Run()
+	}
+}
+
+// Remove stops and removes the service entirely (container rm, systemd
+// disable, or killing the process).
+func (s Service) Remove() error {
+	switch s.Kind {
+	case ContainerKind:
+		if err := s.Stop(); err != nil {
+			return err
+		}
+		return exec.Command(s.Runtime, "rm", s.Name).Run()
+	case SystemdKind:
+		if err := s.Stop(); err != nil {
+			return err
+		}
+		return exec.Command("sudo", "systemctl", "disable", s.Name).Run()
+	default:
+		return s.Stop()
+	}
+}
+
+func (s Service) output(name string, args ...string) (string, error) {
+	out, err := exec.Command(name, args...).CombinedOutput()
+	return string(out), err
+}
+
+func (s Service) startPiped(name string, args ...string) (io.ReadCloser, error) {
+	return procutil.StartPiped(name, args...)
+}
@@ -0,0 +1,304 @@
+// Package panels implements the lazydocker-style service panel: a
+// bubbletea sub-model with logs/stats/env/config/top tabs that can be
+// embedded in the installer's doctorServiceScreen.
+package panels
+
+import (
+	"bufio"
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/strawberry-code/ragify/internal/services"
+)
+
+// Tab identifies which sub-view of a service is showing.
+type Tab int
+
+const (
+	LogsTab Tab = iota
+	StatsTab
+	EnvTab
+	ConfigTab
+	TopTab
+)
+
+func (t Tab) String() string {
+	switch t {
+	case LogsTab:
+		return "Logs"
+	case StatsTab:
+		return "Stats"
+	case EnvTab:
+		return "Env"
+	case ConfigTab:
+		return "Config"
+	case TopTab:
+		return "Top"
+	default:
+		return "?"
+	}
+}
+
+var tabOrder = []Tab{LogsTab, StatsTab, EnvTab, ConfigTab, TopTab}
+
+// tabContentMsg carries the result of a one-shot fetch (stats/env/config/top).
+type tabContentMsg struct {
+	text string
+	err  error
+}
+
+// logLineMsg carries a single line from a followed log stream, plus the
+// channels needed to keep reading, so Update can pick them up from the
+// message (Init, a value receiver, can't store them on the model).
+type logLineMsg struct {
+	line string
+	done bool
+	src  chan string
+	stop chan struct{}
+}
+
+// actionDoneMsg reports the outcome of a restart/stop/remove action.
+type actionDoneMsg struct {
+	action string
+	err    error
+}
+
+var (
+	tabBarStyle    = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262"))
+	activeTabStyle = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF69B4")).Bold(true)
+	contentStyle   = lipgloss.NewStyle().MarginLeft(2)
+	helpStyle      = lipgloss.NewStyle().Foreground(lipgloss.Color("#626262")).MarginLeft(2)
+	errStyle       = lipgloss.NewStyle().Foreground(lipgloss.Color("#FF0000"))
+)
+
+// Model is the bubbletea sub-model for the service panel. The caller
+// (doctorServiceScreen) embeds it, forwards messages via Update, and
+// renders it via View.
+type Model struct {
+	svcs       []services.Service
+	contextIdx int
+	tab        Tab
+
+	content  string
+	logLines []string
+	err      error
+	status   string
+
+	logDone chan struct{}
+}
+
+// New builds a panel over the given services, starting on the first one.
+func New(svcs []services.Service) Model {
+	return Model{svcs: svcs, tab: LogsTab}
+}
+
+func (m Model) current() (services.Service, bool) {
+	if len(m.svcs) == 0 {
+		return services.Service{}, false
+	}
+	return m.svcs[m.contextIdx], true
+}
+
+// Init kicks off the fetch for whatever tab/context we start on.
+func (m Model) Init() tea.Cmd {
+	return m.loadTab()
+}
+
+// Update handles key bindings ([ and ] cycle context, r/s/x act on the
+// current service, tab cycles between Logs/Stats/Env/Config/Top) plus the
+// async messages produced by loadTab/streamLogs.
+func (m Model) Update(msg tea.Msg) (Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "[":
+			m.stopStreaming()
+			m.contextIdx = (m.contextIdx - 1 + len(m.svcs)) % max(len(m.svcs), 1)
+			m.content, m.err, m.logLines = "", nil, nil
+			return m, m.loadTab()
+		case "]":
+			m.stopStreaming()
+			m.contextIdx = (m.contextIdx + 1) % max(len(m.svcs), 1)
+			m.content, m.err, m.logLines = "", nil, nil
+			return m, m.loadTab()
+		case "tab":
+			m.stopStreaming()
+			m.tab = tabOrder[(indexOf(m.tab)+1)%len(tabOrder)]
+			m.content, m.err, m.logLines = "", nil, nil
+			return m, m.loadTab()
+		case "r", "s", "x":
+			svc, ok := m.current()
+			if !ok {
+				return m, nil
+			}
+			return m, runAction(svc, msg.String())
+		}
+
+	case tabContentMsg:
+		m.content, m.err = msg.text, msg.err
+
+	case logLineMsg:
+		m.logDone = msg.stop
+		if msg.done {
+			return m, nil
+		}
+		m.logLines = append(m.logLines, msg.line)
+		return m, waitForLine(msg.src, msg.stop)
+
+	case actionDoneMsg:
+		if msg.err != nil {
+			m.status = fmt.Sprintf("%s failed: %v", msg.action, msg.err)
+		} else {
+			m.status = msg.action + " ok"
+		}
+		return m, m.loadTab()
+	}
+
+	return m, nil
+}
+
+// View renders the tab bar, the current tab's content, and the help line.
+func (m Model) View() string {
+	svc, ok := m.current()
+	if !ok {
+		return contentStyle.Render("No services detected.") + "\n"
+	}
+
+	s := fmt.Sprintf(" %s  ", svc.Name)
+	for _, t := range tabOrder {
+		style := tabBarStyle
+		if t == m.tab {
+			style = activeTabStyle
+		}
+		s += style.Render(t.String()) + "  "
+	}
+	s += "\n\n"
+
+	if m.err != nil {
+		s += contentStyle.Render(errStyle.Render(m.err.Error())) + "\n"
+	} else if m.tab == LogsTab {
+		for _, line := range m.logLines {
+			s += contentStyle.Render(line) + "\n"
+		}
+	} else {
+		s += contentStyle.Render(m.content) + "\n"
+	}
+
+	if m.status != "" {
+		s += "\n" + helpStyle.Render(m.status) + "\n"
+	}
+	s += "\n" + helpStyle.Render("[/]: switch service • tab: switch view • r: restart • s: stop • x: remove") + "\n"
+	return s
+}
+
+func (m Model) loadTab() tea.Cmd {
+	svc, ok := m.current()
+	if !ok {
+		return nil
+	}
+
+	switch m.tab {
+	case LogsTab:
+		return streamLogs(svc)
+	case StatsTab:
+		return fetch(svc.Stats)
+	case EnvTab, ConfigTab:
+		return fetch(svc.Env)
+	case TopTab:
+		return fetch(svc.Top)
+	}
+	return nil
+}
+
+func fetch(f func() (string, error)) tea.Cmd {
+	return func() tea.Msg {
+		text, err := f()
+		return tabContentMsg{text: text, err: err}
+	}
+}
+
+// streamLogs starts `docker logs -f` / `journalctl -fu` in the background
+// and pipes each line back into the bubbletea loop as a logLineMsg.
+func streamLogs(svc services.Service) tea.Cmd {
+	lines := make(chan string, 64)
+	done := make(chan struct{})
+
+	go func() {
+		rc, err := svc.Logs(true)
+		if err != nil {
+			close(lines)
+			return
+		}
+		defer rc.Close()
+
+		scanner := bufio.NewScanner(rc)
+		for scanner.Scan() {
+			select {
+			case lines <- scanner.Text():
+			case <-done:
+				return
+			}
+		}
+		close(lines)
+	}()
+
+	return waitForLine(lines, done)
+}
+
+func waitForLine(lines chan string, done chan struct{}) tea.Cmd {
+	return func() tea.Msg {
+		select {
+		case line, ok := <-lines:
+			if !ok {
+				return logLineMsg{done: true, src: lines, stop: done}
+			}
+			return logLineMsg{line: line, src: lines, stop: done}
+		case <-done:
+			// stopStreaming closed done while we were blocked on lines;
+			// unblock instead of leaking this goroutine until the
+			// abandoned producer happens to close lines on its own.
+			return logLineMsg{done: true, src: lines, stop: done}
+		}
+	}
+}
+
+func (m *Model) stopStreaming() {
+	if m.logDone != nil {
+		close(m.logDone)
+		m.logDone = nil
+	}
+}
+
+func runAction(svc services.Service, key string) tea.Cmd {
+	return func() tea.Msg {
+		var err error
+		var action string
+		switch key {
+		case "r":
+			action, err = "restart", svc.Restart()
+		case "s":
+			action, err = "stop", svc.Stop()
+		case "x":
+			action, err = "remove", svc.Remove()
+		}
+		return actionDoneMsg{action: action, err: err}
+	}
+}
+
+func indexOf(t Tab) int {
+	for i, o := range tabOrder {
+		if o == t {
+			return i
+		}
+	}
+	return 0
+}
+
+func max(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}